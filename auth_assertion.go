@@ -0,0 +1,36 @@
+package paypal
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+)
+
+// MerchantSubject identifies the seller a partner acts on behalf of, by payer ID (merchant ID) or email
+type MerchantSubject struct {
+	PayerID string `json:"payer_id,omitempty"`
+	Email   string `json:"email,omitempty"`
+}
+
+// AuthAssertion returns the unsigned JWT sent in the PayPal-Auth-Assertion header
+// by the partner with the given client ID to act on behalf of the merchant
+// https://developer.paypal.com/api/rest/requests/#link-paypalauthassertion
+func AuthAssertion(clientID string, merchant MerchantSubject) string {
+	header, _ := json.Marshal(map[string]string{"alg": "none"})
+	payload, _ := json.Marshal(struct {
+		Issuer string `json:"iss"`
+		MerchantSubject
+	}{Issuer: clientID, MerchantSubject: merchant})
+
+	return base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload) + "."
+}
+
+// MerchantContext returns a context making every call of the client act on behalf of the merchant,
+// e.g. to create orders, capture or refund payments of a connected seller.
+// Calls carry the PayPal-Auth-Assertion header and share the access token of the client.
+//
+//	ctx := c.MerchantContext(ctx, paypal.MerchantSubject{PayerID: merchantID})
+//	order, err := c.CreateOrder(ctx, paypal.OrderIntentCapture, units, nil, nil)
+func (c *Client) MerchantContext(ctx context.Context, merchant MerchantSubject) context.Context {
+	return withCallOptions(ctx, []CallOption{WithAuthAssertion(AuthAssertion(c.ClientID, merchant))})
+}
@@ -0,0 +1,58 @@
+package paypal
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestAuthAssertion(t *testing.T) {
+	assertion := AuthAssertion("clientID", MerchantSubject{PayerID: "MERCHANT"})
+
+	parts := strings.Split(assertion, ".")
+	if len(parts) != 3 || parts[2] != "" {
+		t.Fatalf("expected an unsigned JWT, got %q", assertion)
+	}
+	header, _ := base64.RawURLEncoding.DecodeString(parts[0])
+	payload, _ := base64.RawURLEncoding.DecodeString(parts[1])
+	if string(header) != `{"alg":"none"}` {
+		t.Errorf("unexpected header %s", header)
+	}
+	if string(payload) != `{"iss":"clientID","payer_id":"MERCHANT"}` {
+		t.Errorf("unexpected payload %s", payload)
+	}
+}
+
+func TestMerchantContext(t *testing.T) {
+	var tokenRequests atomic.Int32
+	var assertion atomic.Value
+	server := newTokenTestServer(t, &tokenRequests, func(w http.ResponseWriter, r *http.Request) {
+		assertion.Store(r.Header.Get("PayPal-Auth-Assertion"))
+		_, _ = w.Write([]byte(`{"id":"O-1"}`))
+	})
+	c, err := NewClient("clientID", "secret", server.URL)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	merchantCtx := c.MerchantContext(context.Background(), MerchantSubject{Email: "seller@example.com"})
+	if _, err := c.GetOrder(merchantCtx, "O-1"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if assertion.Load() != AuthAssertion("clientID", MerchantSubject{Email: "seller@example.com"}) {
+		t.Errorf("unexpected assertion %v", assertion.Load())
+	}
+
+	if _, err := c.GetOrder(context.Background(), "O-1"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if assertion.Load() != "" {
+		t.Errorf("expected no assertion outside of the merchant context, got %v", assertion.Load())
+	}
+	if tokenRequests.Load() != 1 {
+		t.Errorf("expected the token to be shared, got %d token requests", tokenRequests.Load())
+	}
+}
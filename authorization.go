@@ -9,7 +9,9 @@ import (
 
 // GetAuthorization returns an authorization by ID
 // Endpoint: GET /v2/payments/authorizations/ID
-func (c *Client) GetAuthorization(ctx context.Context, authID string) (*Authorization, error) {
+func (c *Client) GetAuthorization(ctx context.Context, authID string, opts ...CallOption) (*Authorization, error) {
+	ctx = withCallOptions(ctx, opts)
+
 	buf := bytes.NewBuffer([]byte(""))
 	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s%s%s", c.APIBase, "/v2/payments/authorizations/", authID), buf)
 	auth := &Authorization{}
@@ -25,8 +27,8 @@ func (c *Client) GetAuthorization(ctx context.Context, authID string) (*Authoriz
 // CaptureAuthorization captures and process an existing authorization.
 // To use this method, the original payment must have Intent set to "authorize"
 // Endpoint: POST /v2/payments/authorizations/ID/capture
-func (c *Client) CaptureAuthorization(ctx context.Context, authID string, paymentCaptureRequest *PaymentCaptureRequest) (*PaymentCaptureResponse, error) {
-	return c.CaptureAuthorizationWithPaypalRequestId(ctx, authID, paymentCaptureRequest, "")
+func (c *Client) CaptureAuthorization(ctx context.Context, authID string, paymentCaptureRequest *PaymentCaptureRequest, opts ...CallOption) (*PaymentCaptureResponse, error) {
+	return c.CaptureAuthorizationWithPaypalRequestId(ctx, authID, paymentCaptureRequest, "", opts...)
 }
 
 // CaptureAuthorization captures and process an existing authorization with idempotency.
@@ -36,7 +38,10 @@ func (c *Client) CaptureAuthorizationWithPaypalRequestId(ctx context.Context,
 	authID string,
 	paymentCaptureRequest *PaymentCaptureRequest,
 	requestID string,
+	opts ...CallOption,
 ) (*PaymentCaptureResponse, error) {
+	ctx = withCallOptions(ctx, opts)
+
 	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s", c.APIBase, "/v2/payments/authorizations/"+authID+"/capture"), paymentCaptureRequest)
 	paymentCaptureResponse := &PaymentCaptureResponse{}
 
@@ -54,7 +59,9 @@ func (c *Client) CaptureAuthorizationWithPaypalRequestId(ctx context.Context,
 
 // VoidAuthorization voids a previously authorized payment
 // Endpoint: POST /v2/payments/authorizations/ID/void
-func (c *Client) VoidAuthorization(ctx context.Context, authID string) (*Authorization, error) {
+func (c *Client) VoidAuthorization(ctx context.Context, authID string, opts ...CallOption) (*Authorization, error) {
+	ctx = withCallOptions(ctx, opts)
+
 	buf := bytes.NewBuffer([]byte(""))
 	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s%s", c.APIBase, "/v2/payments/authorizations/"+authID+"/void"), buf)
 	auth := &Authorization{}
@@ -70,7 +77,9 @@ func (c *Client) VoidAuthorization(ctx context.Context, authID string) (*Authori
 // ReauthorizeAuthorization reauthorize a Paypal account payment.
 // PayPal recommends reauthorizing payment after ~3 days
 // Endpoint: POST /v2/payments/authorizations/ID/reauthorize
-func (c *Client) ReauthorizeAuthorization(ctx context.Context, authID string, a *Amount) (*Authorization, error) {
+func (c *Client) ReauthorizeAuthorization(ctx context.Context, authID string, a *Amount, opts ...CallOption) (*Authorization, error) {
+	ctx = withCallOptions(ctx, opts)
+
 	buf := bytes.NewBuffer([]byte(`{"amount":{"currency_code":"` + a.Currency + `","value":"` + a.Total + `"}}`))
 	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s%s", c.APIBase, "/v2/payments/authorizations/"+authID+"/reauthorize"), buf)
 	auth := &Authorization{}
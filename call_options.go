@@ -0,0 +1,158 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+type (
+	// CallOption customizes a single API call, e.g. to set PayPal headers or a timeout.
+	// All Client methods calling the API accept call options as their last arguments.
+	CallOption func(*callOptions)
+
+	callOptions struct {
+		header         http.Header
+		timeout        time.Duration
+		responseMeta   *ResponseMeta
+		idempotencyKey string
+		accessToken    string
+	}
+)
+
+type callOptionsKey struct{}
+
+// WithHeader sets a request header for the call
+func WithHeader(name, value string) CallOption {
+	return func(o *callOptions) {
+		if o.header == nil {
+			o.header = make(http.Header)
+		}
+		o.header.Set(name, value)
+	}
+}
+
+// WithPrefer sets the Prefer header of the call, return=minimal or return=representation
+// https://developer.paypal.com/api/rest/requests/#http-request-headers
+func WithPrefer(prefer string) CallOption {
+	return WithHeader("Prefer", prefer)
+}
+
+// WithReturnRepresentation makes PayPal return the full resource, unlike SetReturnRepresentation only for the call
+func WithReturnRepresentation() CallOption {
+	return WithPrefer("return=representation")
+}
+
+// WithReturnMinimal makes PayPal return only the ID, status and HATEOAS links of the resource
+func WithReturnMinimal() CallOption {
+	return WithPrefer("return=minimal")
+}
+
+// WithRequestID sets the PayPal-Request-Id header making the call idempotent
+func WithRequestID(requestID string) CallOption {
+	return WithHeader("PayPal-Request-Id", requestID)
+}
+
+// WithPartnerAttributionID sets the PayPal-Partner-Attribution-Id header (BN code) of the call
+func WithPartnerAttributionID(bnCode string) CallOption {
+	return WithHeader("PayPal-Partner-Attribution-Id", bnCode)
+}
+
+// WithAuthAssertion sets the PayPal-Auth-Assertion header to call the API on behalf of a merchant
+func WithAuthAssertion(assertion string) CallOption {
+	return WithHeader("PayPal-Auth-Assertion", assertion)
+}
+
+// WithClientMetadataID sets the PayPal-Client-Metadata-Id header, the risk session of the buyer
+func WithClientMetadataID(id string) CallOption {
+	return WithHeader("PayPal-Client-Metadata-Id", id)
+}
+
+// WithMockResponse makes the sandbox simulate an error for the call, e.g. MockApplicationCodes "INSTRUMENT_DECLINED"
+func WithMockResponse(mock MockResponse) CallOption {
+	value, _ := json.Marshal(mock)
+	return WithHeader("PayPal-Mock-Response", string(value))
+}
+
+// WithAccessToken makes the call with the access token of a user obtained with ExchangeAuthorizationCode
+// instead of the token of the client, e.g. for GetUserInfo.
+// The token is neither refreshed nor invalidated by the client.
+func WithAccessToken(accessToken string) CallOption {
+	return func(o *callOptions) {
+		o.accessToken = accessToken
+	}
+}
+
+// WithTimeout limits the duration of the call, retries included
+func WithTimeout(timeout time.Duration) CallOption {
+	return func(o *callOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithResponseMeta records the response metadata of the call in meta, see ContextWithResponseMeta
+func WithResponseMeta(meta *ResponseMeta) CallOption {
+	return func(o *callOptions) {
+		o.responseMeta = meta
+	}
+}
+
+// WithIdempotencyKey derives the PayPal-Request-Id of the call from key, see ContextWithIdempotencyKey
+func WithIdempotencyKey(key string) CallOption {
+	return func(o *callOptions) {
+		o.idempotencyKey = key
+	}
+}
+
+// withCallOptions returns a context carrying the call options to Send.
+// Options are added to the ones already in ctx, so methods calling other methods keep them.
+func withCallOptions(ctx context.Context, opts []CallOption) context.Context {
+	if len(opts) == 0 {
+		return ctx
+	}
+
+	options := callOptionsFromContext(ctx)
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.responseMeta != nil {
+		ctx = ContextWithResponseMeta(ctx, options.responseMeta)
+	}
+	if options.idempotencyKey != "" {
+		ctx = ContextWithIdempotencyKey(ctx, options.idempotencyKey)
+	}
+
+	return context.WithValue(ctx, callOptionsKey{}, options)
+}
+
+// callOptionsFromContext returns a copy of the call options in ctx
+func callOptionsFromContext(ctx context.Context) callOptions {
+	options, _ := ctx.Value(callOptionsKey{}).(callOptions)
+	options.header = options.header.Clone()
+	return options
+}
+
+// tokenRequestContext drops the options of the API call from the context of the token request made for it
+func tokenRequestContext(ctx context.Context) context.Context {
+	ctx = withoutResponseMeta(ctx)
+	if ctx.Value(callOptionsKey{}) == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, callOptionsKey{}, nil)
+}
+
+// applyCallOptions sets the headers of the call options on the request and applies their timeout.
+// The returned cancel function must be called once the response is read.
+func applyCallOptions(req *http.Request) (*http.Request, context.CancelFunc) {
+	options := callOptionsFromContext(req.Context())
+	for name, values := range options.header {
+		req.Header[name] = values
+	}
+
+	if options.timeout <= 0 {
+		return req, func() {}
+	}
+	ctx, cancel := context.WithTimeout(req.Context(), options.timeout)
+	return req.WithContext(ctx), cancel
+}
@@ -0,0 +1,89 @@
+package paypal
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCallOptions_Headers(t *testing.T) {
+	var header http.Header
+	c := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+		_, _ = w.Write([]byte(`{"id":"O-1"}`))
+	})
+
+	_, err := c.CaptureOrder(context.Background(), "O-1", CaptureOrderRequest{},
+		WithReturnMinimal(),
+		WithPartnerAttributionID("BN-CODE"),
+		WithClientMetadataID("risk-session"),
+		WithRequestID("request-1"),
+	)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := map[string]string{
+		"Prefer":                        "return=minimal",
+		"Paypal-Partner-Attribution-Id": "BN-CODE",
+		"Paypal-Client-Metadata-Id":     "risk-session",
+		"Paypal-Request-Id":             "request-1",
+	}
+	for name, value := range expected {
+		if header.Get(name) != value {
+			t.Errorf("expected %s %q, got %q", name, value, header.Get(name))
+		}
+	}
+
+	// Options apply to a single call
+	if _, err := c.CaptureOrder(context.Background(), "O-1", CaptureOrderRequest{}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if header.Get("Prefer") != "return=representation" || header.Get("Paypal-Partner-Attribution-Id") != "" {
+		t.Errorf("expected default headers, got %v", header)
+	}
+}
+
+func TestCallOptions_NotSentWithTokenRequest(t *testing.T) {
+	var tokenRequests atomic.Int32
+	var tokenAssertion atomic.Value
+	server := newTokenTestServer(t, &tokenRequests, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"O-1"}`))
+	})
+	c, err := NewClient("clientID", "secret", server.URL)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	c.Use(func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path == "/v1/oauth2/token" {
+				tokenAssertion.Store(req.Header.Get("PayPal-Auth-Assertion"))
+			}
+			return next(req)
+		}
+	})
+
+	if _, err := c.GetOrder(context.Background(), "O-1", WithAuthAssertion("assertion")); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if tokenAssertion.Load() != "" {
+		t.Errorf("expected no PayPal-Auth-Assertion on the token request, got %v", tokenAssertion.Load())
+	}
+}
+
+func TestCallOptions_Timeout(t *testing.T) {
+	c := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	})
+
+	_, err := c.GetOrder(context.Background(), "O-1", WithTimeout(10*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+}
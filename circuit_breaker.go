@@ -0,0 +1,272 @@
+package paypal
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is matched by the *CircuitOpenError returned while the circuit of an API family is open
+var ErrCircuitOpen = errors.New("paypal: circuit open")
+
+// CircuitState is the state of the circuit of an API family
+type CircuitState int
+
+// Possible values of CircuitState
+const (
+	// CircuitClosed lets all requests through
+	CircuitClosed CircuitState = iota
+	// CircuitOpen fails all requests immediately
+	CircuitOpen
+	// CircuitHalfOpen lets a few probe requests through to check whether PayPal recovered
+	CircuitHalfOpen
+)
+
+// String implements fmt.Stringer
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+	return "closed"
+}
+
+type (
+	// CircuitBreakerConfig configures a CircuitBreaker
+	CircuitBreakerConfig struct {
+		// FailureThreshold is the number of consecutive failures opening the circuit, 5 if not set
+		FailureThreshold int
+		// OpenTimeout is how long the circuit stays open before probing PayPal again, 30s if not set
+		OpenTimeout time.Duration
+		// HalfOpenRequests is the number of concurrent probes in the half-open state, 1 if not set
+		HalfOpenRequests int
+		// OnStateChange is called when the circuit of an API family changes state, e.g. to switch to a fallback.
+		// Changes are delivered in order, one at a time, by the goroutine of a request once the breaker is unlocked.
+		OnStateChange func(family string, from, to CircuitState)
+	}
+
+	// CircuitBreaker fails requests fast while PayPal is failing. Circuits are tracked per API family,
+	// the first three segments of the path (e.g. /v2/checkout/orders). 5xx responses, timeouts
+	// and connection failures count as failures.
+	CircuitBreaker struct {
+		config   CircuitBreakerConfig
+		mu       sync.Mutex
+		circuits map[string]*familyCircuit
+		// changes are the state changes waiting to be passed to OnStateChange
+		changes    []circuitStateChange
+		delivering bool
+	}
+
+	// CircuitOpenError is returned without sending the request while the circuit of its API family is open
+	CircuitOpenError struct {
+		Family string
+		// Until is when the circuit will let a probe request through
+		Until time.Time
+	}
+
+	circuitStateChange struct {
+		family   string
+		from, to CircuitState
+	}
+
+	familyCircuit struct {
+		state    CircuitState
+		failures int
+		openedAt time.Time
+		probes   int
+		// generation changes with the state, outcomes of requests admitted in another generation are ignored
+		generation int
+	}
+)
+
+// Error implements error
+func (e *CircuitOpenError) Error() string {
+	return "paypal: circuit open for " + e.Family + " until " + e.Until.Format(time.RFC3339)
+}
+
+// Is makes a CircuitOpenError match ErrCircuitOpen
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+// NewCircuitBreaker returns a CircuitBreaker with all circuits closed
+func NewCircuitBreaker(config CircuitBreakerConfig) *CircuitBreaker {
+	if config.FailureThreshold <= 0 {
+		config.FailureThreshold = 5
+	}
+	if config.OpenTimeout <= 0 {
+		config.OpenTimeout = 30 * time.Second
+	}
+	if config.HalfOpenRequests <= 0 {
+		config.HalfOpenRequests = 1
+	}
+
+	return &CircuitBreaker{config: config, circuits: make(map[string]*familyCircuit)}
+}
+
+// SetCircuitBreaker makes the client fail requests immediately with a *CircuitOpenError
+// while PayPal is failing, see CircuitBreaker
+func (c *Client) SetCircuitBreaker(breaker *CircuitBreaker) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.circuitBreaker = breaker
+}
+
+// State returns the state of the circuit of an API family
+func (b *CircuitBreaker) State(family string) CircuitState {
+	b.mu.Lock()
+	defer b.unlock()
+
+	if circuit, ok := b.circuits[family]; ok {
+		return b.currentStateLocked(family, circuit)
+	}
+	return CircuitClosed
+}
+
+// apiFamily returns the first three segments of the path
+func apiFamily(path string) string {
+	segments := strings.SplitN(strings.TrimPrefix(path, "/"), "/", 4)
+	if len(segments) > 3 {
+		segments = segments[:3]
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+// allow returns an error if the request must not be sent, or a function recording its outcome
+func (b *CircuitBreaker) allow(req *http.Request) (func(err error), error) {
+	family := apiFamily(req.URL.Path)
+
+	b.mu.Lock()
+	defer b.unlock()
+
+	circuit, ok := b.circuits[family]
+	if !ok {
+		circuit = &familyCircuit{}
+		b.circuits[family] = circuit
+	}
+
+	switch b.currentStateLocked(family, circuit) {
+	case CircuitOpen:
+		return nil, &CircuitOpenError{Family: family, Until: circuit.openedAt.Add(b.config.OpenTimeout)}
+	case CircuitHalfOpen:
+		if circuit.probes >= b.config.HalfOpenRequests {
+			return nil, &CircuitOpenError{Family: family, Until: time.Now()}
+		}
+		circuit.probes++
+	}
+
+	ctx, generation := req.Context(), circuit.generation
+	return func(err error) {
+		// A request the caller canceled or gave up on says nothing about PayPal
+		callerGaveUp := errors.Is(err, context.Canceled) || ctx.Err() != nil
+		b.record(family, circuit, generation, callerGaveUp, err)
+	}, nil
+}
+
+// record updates the circuit with the outcome of a request admitted in generation.
+// Requests admitted before the last state change, e.g. slow requests sent before the circuit opened,
+// are ignored: only probes of the current half-open state can close the circuit.
+func (b *CircuitBreaker) record(family string, circuit *familyCircuit, generation int, callerGaveUp bool, err error) {
+	b.mu.Lock()
+	defer b.unlock()
+
+	if circuit.generation != generation {
+		return
+	}
+	if circuit.state == CircuitHalfOpen && circuit.probes > 0 {
+		circuit.probes--
+	}
+	if callerGaveUp {
+		return
+	}
+
+	if !isCircuitFailure(err) {
+		circuit.failures = 0
+		if circuit.state == CircuitHalfOpen {
+			b.setStateLocked(family, circuit, CircuitClosed)
+		}
+		return
+	}
+
+	circuit.failures++
+	if circuit.state == CircuitHalfOpen || (circuit.state == CircuitClosed && circuit.failures >= b.config.FailureThreshold) {
+		circuit.openedAt = time.Now()
+		b.setStateLocked(family, circuit, CircuitOpen)
+	}
+}
+
+// currentStateLocked moves an open circuit to half-open once OpenTimeout elapsed
+func (b *CircuitBreaker) currentStateLocked(family string, circuit *familyCircuit) CircuitState {
+	if circuit.state == CircuitOpen && time.Since(circuit.openedAt) >= b.config.OpenTimeout {
+		circuit.probes = 0
+		b.setStateLocked(family, circuit, CircuitHalfOpen)
+	}
+	return circuit.state
+}
+
+func (b *CircuitBreaker) setStateLocked(family string, circuit *familyCircuit, state CircuitState) {
+	if circuit.state == state {
+		return
+	}
+
+	from := circuit.state
+	circuit.state = state
+	circuit.generation++
+	if state == CircuitClosed {
+		circuit.failures = 0
+	}
+	if b.config.OnStateChange != nil {
+		b.changes = append(b.changes, circuitStateChange{family: family, from: from, to: state})
+	}
+}
+
+// unlock releases the lock and passes the pending state changes to OnStateChange,
+// unless another goroutine is already delivering them
+func (b *CircuitBreaker) unlock() {
+	if b.delivering || len(b.changes) == 0 {
+		b.mu.Unlock()
+		return
+	}
+	b.delivering = true
+
+	for len(b.changes) > 0 {
+		changes := b.changes
+		b.changes = nil
+		b.mu.Unlock()
+
+		for _, change := range changes {
+			b.config.OnStateChange(change.family, change.from, change.to)
+		}
+
+		b.mu.Lock()
+	}
+	b.delivering = false
+	b.mu.Unlock()
+}
+
+// isCircuitFailure reports whether err shows PayPal is failing: a 5xx response, a timeout or a connection failure.
+// Errors caused by the request itself are not failures.
+func isCircuitFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var errResp *ErrorResponse
+	if errors.As(err, &errResp) {
+		return errResp.Response != nil && errResp.Response.StatusCode >= http.StatusInternalServerError
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var netErr net.Error
+	var opErr *net.OpError
+	return (errors.As(err, &netErr) && netErr.Timeout()) || errors.As(err, &opErr)
+}
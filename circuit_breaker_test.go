@@ -0,0 +1,198 @@
+package paypal
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	var (
+		failing atomic.Bool
+		calls   atomic.Int32
+	)
+	failing.Store(true)
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if failing.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"name":"SERVICE_UNAVAILABLE"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	var changes []CircuitState
+	breaker := NewCircuitBreaker(CircuitBreakerConfig{
+		FailureThreshold: 2,
+		OpenTimeout:      20 * time.Millisecond,
+		OnStateChange: func(family string, from, to CircuitState) {
+			if family == "/v2/checkout/orders" {
+				changes = append(changes, to)
+			}
+		},
+	})
+	c.SetCircuitBreaker(breaker)
+
+	for range 2 {
+		if _, err := c.GetOrder(context.Background(), "O-1"); !IsServer(err) {
+			t.Fatalf("expected server error, got %v", err)
+		}
+	}
+	if state := breaker.State("/v2/checkout/orders"); state != CircuitOpen {
+		t.Fatalf("expected open circuit, got %s", state)
+	}
+
+	_, err := c.GetOrder(context.Background(), "O-1")
+	var openErr *CircuitOpenError
+	if !errors.Is(err, ErrCircuitOpen) || !errors.As(err, &openErr) || openErr.Family != "/v2/checkout/orders" {
+		t.Fatalf("expected open circuit error, got %v", err)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("expected no request while open, got %d calls", calls.Load())
+	}
+	if _, err := c.GetPayout(context.Background(), "B-1"); errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("expected other families to stay closed, got %v", err)
+	}
+
+	// A failed probe opens the circuit again, a successful one closes it
+	time.Sleep(25 * time.Millisecond)
+	if _, err := c.GetOrder(context.Background(), "O-1"); !IsServer(err) {
+		t.Fatalf("expected probe to be sent, got %v", err)
+	}
+	if state := breaker.State("/v2/checkout/orders"); state != CircuitOpen {
+		t.Fatalf("expected open circuit after failed probe, got %s", state)
+	}
+
+	failing.Store(false)
+	time.Sleep(25 * time.Millisecond)
+	if _, err := c.GetOrder(context.Background(), "O-1"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if state := breaker.State("/v2/checkout/orders"); state != CircuitClosed {
+		t.Errorf("expected closed circuit, got %s", state)
+	}
+
+	// State changes are delivered before the request returns, in order
+	want := []CircuitState{CircuitOpen, CircuitHalfOpen, CircuitOpen, CircuitHalfOpen, CircuitClosed}
+	if !slices.Equal(changes, want) {
+		t.Errorf("expected state changes %v, got %v", want, changes)
+	}
+}
+
+func TestCircuitBreaker_ClientErrorsAreNotFailures(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"name":"UNPROCESSABLE_ENTITY"}`))
+	})
+	breaker := NewCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1})
+	c.SetCircuitBreaker(breaker)
+
+	for range 3 {
+		if _, err := c.GetOrder(context.Background(), "O-1"); errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("expected 4xx not to open the circuit, got %v", err)
+		}
+	}
+}
+
+func TestCircuitBreaker_CallerCancellationIsNotRecorded(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+	breaker := NewCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1})
+	c.SetCircuitBreaker(breaker)
+
+	for range 2 {
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(10*time.Millisecond, cancel)
+		if _, err := c.GetOrder(ctx, "O-1"); !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected the request to be canceled, got %v", err)
+		}
+	}
+	if state := breaker.State("/v2/checkout/orders"); state != CircuitClosed {
+		t.Errorf("expected canceled requests not to open the circuit, got %s", state)
+	}
+}
+
+func TestCircuitBreaker_RateLimiterWaitIsNotRecorded(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	})
+	limiter, _ := newFakeClockRateLimiter(RateLimit{}, map[string]RateLimit{APIFamilyReporting: {Rate: 1, Burst: 1}})
+	c.SetRateLimiter(limiter)
+	breaker := NewCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1})
+	c.SetCircuitBreaker(breaker)
+
+	if _, err := c.ListTransactions(context.Background(), &TransactionSearchRequest{}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := c.ListTransactions(ctx, &TransactionSearchRequest{}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the wait to stop with the context, got %v", err)
+	}
+	if state := breaker.State("/v1/reporting/transactions"); state != CircuitClosed {
+		t.Errorf("expected the limiter deadline not to open the circuit, got %s", state)
+	}
+}
+
+func TestCircuitBreaker_CallerDeadlineIsNotRecorded(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+	breaker := NewCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1})
+	c.SetCircuitBreaker(breaker)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := c.GetOrder(ctx, "O-1"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the request to time out, got %v", err)
+	}
+	if state := breaker.State("/v2/checkout/orders"); state != CircuitClosed {
+		t.Errorf("expected the caller deadline not to open the circuit, got %s", state)
+	}
+}
+
+func TestCircuitBreaker_StaleSuccessDoesNotClose(t *testing.T) {
+	arrived, release := make(chan struct{}), make(chan struct{})
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v2/checkout/orders/SLOW" {
+			close(arrived)
+			<-release
+			_, _ = w.Write([]byte(`{}`))
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"name":"SERVICE_UNAVAILABLE"}`))
+	})
+	breaker := NewCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1, OpenTimeout: 20 * time.Millisecond})
+	c.SetCircuitBreaker(breaker)
+
+	// A slow request is admitted while the circuit is closed
+	slow := make(chan error)
+	go func() {
+		_, err := c.GetOrder(context.Background(), "SLOW")
+		slow <- err
+	}()
+	<-arrived
+
+	if _, err := c.GetOrder(context.Background(), "O-1"); !IsServer(err) {
+		t.Fatalf("expected server error, got %v", err)
+	}
+	time.Sleep(25 * time.Millisecond)
+	if state := breaker.State("/v2/checkout/orders"); state != CircuitHalfOpen {
+		t.Fatalf("expected half-open circuit, got %s", state)
+	}
+
+	close(release)
+	if err := <-slow; err != nil {
+		t.Fatalf("expected the slow request to succeed, got %v", err)
+	}
+	if state := breaker.State("/v2/checkout/orders"); state != CircuitHalfOpen {
+		t.Errorf("expected the success of a request admitted before opening not to close the circuit, got %s", state)
+	}
+}
@@ -30,7 +30,9 @@ func NewClient(clientID string, secret string, APIBase string) (*Client, error)
 // GetAccessToken returns struct of TokenResponse
 // No need to call SetAccessToken to apply new access token for current Client
 // Endpoint: POST /v1/oauth2/token
-func (c *Client) GetAccessToken(ctx context.Context) (*TokenResponse, error) {
+func (c *Client) GetAccessToken(ctx context.Context, opts ...CallOption) (*TokenResponse, error) {
+	ctx = withCallOptions(ctx, opts)
+
 	buf := bytes.NewBuffer([]byte("grant_type=client_credentials"))
 	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s%s", c.APIBase, "/v1/oauth2/token"), buf)
 	if err != nil {
@@ -44,8 +46,20 @@ func (c *Client) GetAccessToken(ctx context.Context) (*TokenResponse, error) {
 
 	// Set Token fur current Client
 	if response.Token != "" {
+		expiresAt := time.Now().Add(time.Duration(response.ExpiresIn) * time.Second)
+
+		c.mu.Lock()
 		c.Token = response
-		c.tokenExpiresAt = time.Now().Add(time.Duration(response.ExpiresIn) * time.Second)
+		c.tokenExpiresAt = expiresAt
+		store := c.tokenStore
+		c.mu.Unlock()
+
+		if store != nil {
+			stored := &StoredToken{Token: response, ExpiresAt: expiresAt}
+			if saveErr := store.Save(ctx, c.tokenStoreKey(), stored); saveErr != nil && err == nil {
+				err = saveErr
+			}
+		}
 	}
 
 	return response, err
@@ -58,6 +72,9 @@ func (c *Client) SetHTTPClient(client *http.Client) {
 
 // SetAccessToken sets saved token to current client
 func (c *Client) SetAccessToken(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	c.Token = &TokenResponse{
 		Token: token,
 	}
@@ -70,7 +87,7 @@ func (c *Client) SetLog(log io.Writer) {
 	c.Log = log
 }
 
-// SetReturnRepresentation enables verbose response
+// SetReturnRepresentation enables verbose response for all calls, use WithReturnRepresentation for a single call
 // Verbose response: https://developer.paypal.com/docs/api/orders/v2/#orders-authorize-header-parameters
 func (c *Client) SetReturnRepresentation() {
 	c.returnRepresentation = true
@@ -79,13 +96,12 @@ func (c *Client) SetReturnRepresentation() {
 // Send makes a request to the API, the response body will be
 // unmarshalled into v, or if v is an io.Writer, the response will
 // be written to it without decoding
+// Transient failures are retried if a RetryPolicy is set, see SetRetryPolicy
+// The request goes through the middlewares registered with Use
+// The response metadata is recorded if requested with ContextWithResponseMeta
+// POST and PATCH requests get a PayPal-Request-Id, see SetAutoRequestID and ContextWithIdempotencyKey
+// Headers and timeout set with CallOption are applied last
 func (c *Client) Send(req *http.Request, v interface{}) (retErr error) {
-	var (
-		err  error
-		resp *http.Response
-		data []byte
-	)
-
 	// Set default headers
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("Accept-Language", "en_US")
@@ -97,92 +113,273 @@ func (c *Client) Send(req *http.Request, v interface{}) (retErr error) {
 	if c.returnRepresentation {
 		req.Header.Set("Prefer", "return=representation")
 	}
+
+	req, cancel := applyCallOptions(req)
+	defer cancel()
+
+	if err := c.setRequestID(req); err != nil {
+		return err
+	}
+
+	start := time.Now()
+	resp, err := c.roundTrip(req)
+	if meta := responseMetaFromContext(req.Context()); meta != nil {
+		if metaErr := recordResponseMeta(meta, resp, err, time.Since(start)); metaErr != nil && err == nil {
+			_ = resp.Body.Close()
+			return metaErr
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		err := resp.Body.Close()
+		if err != nil && retErr == nil {
+			retErr = err
+		}
+	}()
+
+	if v == nil {
+		return nil
+	}
+
+	if w, ok := v.(io.Writer); ok {
+		_, err := io.Copy(w, resp.Body)
+		return err
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// sendOnce makes a single attempt to send the request.
+// Non-2xx responses are returned as *ErrorResponse with the response body already consumed
+func (c *Client) sendOnce(req *http.Request) (resp *http.Response, err error) {
+	c.mu.Lock()
+	limiter := c.rateLimiter
+	breaker := c.circuitBreaker
+	c.mu.Unlock()
+
+	// Waiting for the limiter comes first, so it neither holds a probe slot of the breaker
+	// nor counts as a failure if the context ends while waiting
+	if limiter != nil {
+		if err := limiter.Wait(req.Context(), req); err != nil {
+			return nil, err
+		}
+	}
+
+	if breaker != nil {
+		record, openErr := breaker.allow(req)
+		if openErr != nil {
+			return nil, openErr
+		}
+		defer func() { record(err) }()
+	}
+
 	if c.Log != nil {
 		if reqDump, err := httputil.DumpRequestOut(req, true); err == nil {
 			logMsg := fmt.Sprintf("Request: %s\n", string(reqDump))
 			if _, logErr := c.Log.Write([]byte(logMsg)); logErr != nil {
-				return logErr
+				return nil, logErr
 			}
 		}
 	}
 
+	logger, logOptions := c.requestLogger()
+	start := time.Now()
+
 	resp, err = c.Client.Do(req)
-	if err != nil {
-		return err
+	if err == nil {
+		if limiter != nil {
+			limiter.observe(req, resp)
+		}
+		resp, err = c.checkResponse(req, resp)
+	}
+
+	if logger != nil {
+		logAttempt(logger, logOptions, req, resp, err, time.Since(start))
 	}
 
+	return resp, err
+}
+
+// checkResponse returns the response if its status code is 2xx, or an *ErrorResponse decoded from its body
+func (c *Client) checkResponse(req *http.Request, resp *http.Response) (*http.Response, error) {
 	if c.Log != nil {
 		if respDump, err := httputil.DumpResponse(resp, true); err == nil {
 			logMsg := fmt.Sprintf("Response from %s: %s\n", req.URL.RequestURI(), string(respDump))
 			if _, logErr := c.Log.Write([]byte(logMsg)); logErr != nil {
-				return logErr
+				_ = resp.Body.Close()
+				return nil, logErr
 			}
 		}
 	}
 
-	defer func() {
-		err := resp.Body.Close()
-		if err != nil && retErr == nil {
-			retErr = err
-		}
-	}()
-
-	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		errResp := &ErrorResponse{Response: resp}
+	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+		return resp, nil
+	}
 
-		var bodyBuffer bytes.Buffer
-		teeReader := io.TeeReader(resp.Body, &bodyBuffer)
-		data, err = io.ReadAll(teeReader)
-		resp.Body = io.NopCloser(&bodyBuffer)
+	errResp := &ErrorResponse{Response: resp}
 
-		if err == nil && len(data) > 0 {
-			err := json.Unmarshal(data, errResp)
-			if err != nil {
-				return err
-			}
-		}
-
-		return errResp
-	}
-	if v == nil {
-		return nil
+	data, err := io.ReadAll(resp.Body)
+	if closeErr := resp.Body.Close(); err == nil {
+		err = closeErr
 	}
+	resp.Body = io.NopCloser(bytes.NewReader(data))
 
-	if w, ok := v.(io.Writer); ok {
-		_, err := io.Copy(w, resp.Body)
-		return err
+	if err == nil && len(data) > 0 {
+		if err := json.Unmarshal(data, errResp); err != nil {
+			return nil, err
+		}
 	}
 
-	return json.NewDecoder(resp.Body).Decode(v)
+	return nil, errResp
 }
 
 // SendWithAuth makes a request to the API and apply OAuth2 header automatically.
 // If the access token soon to be expired or already expired, it will try to get a new one before
 // making the main request
 // client.Token will be updated when changed
+// If PayPal rejects the token with 401 Unauthorized, the token is invalidated
+// and the request is sent once more with a new one
+// A user token set with WithAccessToken is used as is
 func (c *Client) SendWithAuth(req *http.Request, v interface{}) error {
-	// c.Lock()
-	c.mu.Lock()
-	// Note: Here we do not want to `defer c.Unlock()` because we need `c.Send(...)`
-	// to happen outside of the locked section.
+	if token := callOptionsFromContext(req.Context()).accessToken; token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+		return c.Send(req, v)
+	}
 
-	if c.Token == nil || (!c.tokenExpiresAt.IsZero() && time.Until(c.tokenExpiresAt) < RequestNewTokenBeforeExpiresIn) {
-		// c.Token will be updated in GetAccessToken call
-		if _, err := c.GetAccessToken(req.Context()); err != nil {
-			// c.Unlock()
-			c.mu.Unlock()
+	token, err := c.accessToken(tokenRequestContext(req.Context()))
+	if err != nil {
+		return err
+	}
+	if err := c.preflightScopes(req); err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	err = c.Send(req, v)
+
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) || errResp.Response.StatusCode != http.StatusUnauthorized {
+		return err
+	}
+	if req.Body != nil && req.Body != http.NoBody {
+		if req.GetBody == nil {
+			return err
+		}
+		body, bodyErr := req.GetBody()
+		if bodyErr != nil {
 			return err
 		}
+		req.Body = body
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.Token.Token)
-	// Unlock the client mutex before sending the request, this allows multiple requests
-	// to be in progress at the same time.
-	// c.Unlock()
-	c.mu.Unlock()
+	if err := c.invalidateToken(req.Context(), token); err != nil {
+		return err
+	}
+	if token, err = c.accessToken(tokenRequestContext(req.Context())); err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
 	return c.Send(req, v)
 }
 
+// accessToken returns a token valid for at least RequestNewTokenBeforeExpiresIn.
+// Concurrent callers share a single token request. Once the token is about to expire
+// (RefreshTokenInBackgroundBeforeExpiresIn) a new one is requested in the background
+// while the current one is still returned, callers only wait for the new token
+// when the current one expires in less than RequestNewTokenBeforeExpiresIn.
+func (c *Client) accessToken(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	if token := c.Token; token != nil {
+		expiresIn := time.Until(c.tokenExpiresAt)
+		if c.tokenExpiresAt.IsZero() || expiresIn >= RefreshTokenInBackgroundBeforeExpiresIn {
+			c.mu.Unlock()
+			return token.Token, nil
+		}
+		if expiresIn >= RequestNewTokenBeforeExpiresIn {
+			c.refreshTokenLocked(ctx)
+			c.mu.Unlock()
+			return token.Token, nil
+		}
+	}
+	refresh := c.refreshTokenLocked(ctx)
+	c.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case <-refresh.done:
+	}
+	if refresh.err != nil {
+		return "", refresh.err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Token == nil {
+		return "", errors.New("paypal: no access token received")
+	}
+	return c.Token.Token, nil
+}
+
+// refreshTokenLocked starts a token request unless one is already in progress.
+// The request is detached from the cancellation of ctx, so a caller giving up
+// does not fail the refresh for the other callers waiting for it.
+// Must be called with c.mu held.
+func (c *Client) refreshTokenLocked(ctx context.Context) *tokenRefresh {
+	if c.tokenRefresh != nil {
+		return c.tokenRefresh
+	}
+
+	refresh := &tokenRefresh{done: make(chan struct{})}
+	c.tokenRefresh = refresh
+	store := c.tokenStore
+	current := ""
+	if c.Token != nil {
+		current = c.Token.Token
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), tokenRefreshTimeout)
+		defer cancel()
+
+		if store != nil {
+			refresh.err = c.refreshStoredToken(ctx, store, current)
+		} else {
+			_, refresh.err = c.GetAccessToken(ctx)
+		}
+
+		c.mu.Lock()
+		c.tokenRefresh = nil
+		c.mu.Unlock()
+		close(refresh.done)
+	}()
+
+	return refresh
+}
+
+// invalidateToken drops the access token rejected by PayPal from the client and the TokenStore
+func (c *Client) invalidateToken(ctx context.Context, token string) error {
+	c.mu.Lock()
+	if c.Token != nil && c.Token.Token == token {
+		c.Token = nil
+		c.tokenExpiresAt = time.Time{}
+	}
+	store := c.tokenStore
+	c.mu.Unlock()
+
+	// The store is called without holding the lock, its I/O must not block other requests
+	if store != nil {
+		return store.Invalidate(ctx, c.tokenStoreKey(), token)
+	}
+
+	return nil
+}
+
 // SendWithBasicAuth makes a request to the API using clientID:secret basic auth
 func (c *Client) SendWithBasicAuth(req *http.Request, v interface{}) error {
 	req.SetBasicAuth(c.ClientID, c.Secret)
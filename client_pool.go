@@ -0,0 +1,168 @@
+package paypal
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+)
+
+// ErrUnknownTenant is returned by ClientPool.Client for a tenant without credentials
+var ErrUnknownTenant = errors.New("paypal: unknown tenant")
+
+type (
+	// TenantCredentials are the API credentials of a tenant of a ClientPool
+	TenantCredentials struct {
+		ClientID string
+		Secret   string
+		APIBase  string
+	}
+
+	// CredentialsProvider looks up the credentials of a tenant, e.g. in a database or a secret manager.
+	// It returns ErrUnknownTenant if the tenant does not exist.
+	CredentialsProvider func(ctx context.Context, tenant string) (TenantCredentials, error)
+
+	// ClientPool keeps one Client per tenant, created on first use, so each tenant reuses its access token.
+	// All clients share one http.Client and its connections. Once the pool holds MaxClients clients,
+	// the least recently used one is dropped together with its token.
+	ClientPool struct {
+		mu          sync.Mutex
+		httpClient  *http.Client
+		maxClients  int
+		credentials map[string]TenantCredentials
+		provider    CredentialsProvider
+		onNewClient func(tenant string, c *Client)
+		lru         *list.List
+		clients     map[string]*list.Element
+	}
+
+	poolEntry struct {
+		tenant      string
+		credentials TenantCredentials
+		client      *Client
+	}
+)
+
+// NewClientPool returns a pool holding at most maxClients clients (unlimited if 0) sharing httpClient.
+// A new http.Client is used if httpClient is nil.
+func NewClientPool(maxClients int, httpClient *http.Client) *ClientPool {
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+
+	return &ClientPool{
+		httpClient:  httpClient,
+		maxClients:  maxClients,
+		credentials: make(map[string]TenantCredentials),
+		lru:         list.New(),
+		clients:     make(map[string]*list.Element),
+	}
+}
+
+// SetCredentialsProvider sets how credentials of tenants which are not registered are looked up
+func (p *ClientPool) SetCredentialsProvider(provider CredentialsProvider) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.provider = provider
+}
+
+// OnNewClient sets a function configuring every client created by the pool, e.g. to set a RetryPolicy or a logger.
+// It is called before the client is added to the pool and can use the pool.
+func (p *ClientPool) OnNewClient(configure func(tenant string, c *Client)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.onNewClient = configure
+}
+
+// Register sets the credentials of a tenant. Registering new credentials for a tenant, e.g. a rotated secret,
+// replaces its client on next use, calls in progress finish with the previous client.
+func (p *ClientPool) Register(tenant string, credentials TenantCredentials) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.credentials[tenant] = credentials
+	if elem, ok := p.clients[tenant]; ok && elem.Value.(*poolEntry).credentials != credentials {
+		p.removeLocked(elem)
+	}
+}
+
+// Evict drops the client of the tenant, credentials from the CredentialsProvider are looked up again on next use
+func (p *ClientPool) Evict(tenant string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if elem, ok := p.clients[tenant]; ok {
+		p.removeLocked(elem)
+	}
+}
+
+// Len returns the number of clients in the pool
+func (p *ClientPool) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.lru.Len()
+}
+
+// Client returns the client of the tenant, creating it from the registered credentials
+// or the ones returned by the CredentialsProvider
+func (p *ClientPool) Client(ctx context.Context, tenant string) (*Client, error) {
+	p.mu.Lock()
+	if elem, ok := p.clients[tenant]; ok {
+		p.lru.MoveToFront(elem)
+		p.mu.Unlock()
+		return elem.Value.(*poolEntry).client, nil
+	}
+	credentials, registered := p.credentials[tenant]
+	provider := p.provider
+	configure := p.onNewClient
+	p.mu.Unlock()
+
+	if !registered {
+		if provider == nil {
+			return nil, ErrUnknownTenant
+		}
+		var err error
+		if credentials, err = provider(ctx, tenant); err != nil {
+			return nil, err
+		}
+	}
+
+	c, err := NewClient(credentials.ClientID, credentials.Secret, credentials.APIBase)
+	if err != nil {
+		return nil, err
+	}
+	c.SetHTTPClient(p.httpClient)
+	// Called without holding the lock so it can use the pool,
+	// the client is dropped if another caller created one in the meantime
+	if configure != nil {
+		configure(tenant, c)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// Another caller could have created the client in the meantime
+	if elem, ok := p.clients[tenant]; ok {
+		p.lru.MoveToFront(elem)
+		return elem.Value.(*poolEntry).client, nil
+	}
+	// The credentials were rotated in the meantime, the client is only used for this call
+	if current, ok := p.credentials[tenant]; ok && current != credentials {
+		return c, nil
+	}
+	p.clients[tenant] = p.lru.PushFront(&poolEntry{tenant: tenant, credentials: credentials, client: c})
+	for p.maxClients > 0 && p.lru.Len() > p.maxClients {
+		p.removeLocked(p.lru.Back())
+	}
+
+	return c, nil
+}
+
+func (p *ClientPool) removeLocked(elem *list.Element) {
+	p.lru.Remove(elem)
+	delete(p.clients, elem.Value.(*poolEntry).tenant)
+}
@@ -0,0 +1,78 @@
+package paypal
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestClientPool(t *testing.T) {
+	pool := NewClientPool(2, nil)
+	pool.Register("a", TenantCredentials{ClientID: "a", Secret: "secret", APIBase: APIBaseSandBox})
+	pool.Register("b", TenantCredentials{ClientID: "b", Secret: "secret", APIBase: APIBaseSandBox})
+	pool.SetCredentialsProvider(func(ctx context.Context, tenant string) (TenantCredentials, error) {
+		if tenant != "c" {
+			return TenantCredentials{}, ErrUnknownTenant
+		}
+		return TenantCredentials{ClientID: "c", Secret: "secret", APIBase: APIBaseLive}, nil
+	})
+
+	ctx := context.Background()
+	a, err := pool.Client(ctx, "a")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if again, _ := pool.Client(ctx, "a"); again != a {
+		t.Errorf("expected the client to be reused")
+	}
+	b, _ := pool.Client(ctx, "b")
+	if a.Client != b.Client {
+		t.Errorf("expected clients to share the http client")
+	}
+
+	// a is the least recently used client once b is created
+	if _, err := pool.Client(ctx, "c"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if pool.Len() != 2 {
+		t.Errorf("expected 2 clients, got %d", pool.Len())
+	}
+	if again, _ := pool.Client(ctx, "a"); again == a {
+		t.Errorf("expected the least recently used client to be evicted")
+	}
+
+	// Rotating the secret replaces the client
+	pool.Register("a", TenantCredentials{ClientID: "a", Secret: "rotated", APIBase: APIBaseSandBox})
+	rotated, _ := pool.Client(ctx, "a")
+	if rotated.Secret != "rotated" {
+		t.Errorf("expected a client with the rotated secret")
+	}
+
+	if _, err := pool.Client(ctx, "d"); !errors.Is(err, ErrUnknownTenant) {
+		t.Errorf("expected unknown tenant, got %v", err)
+	}
+}
+
+func TestClientPool_OnNewClientCanUseThePool(t *testing.T) {
+	pool := NewClientPool(0, nil)
+	pool.Register("a", TenantCredentials{ClientID: "a", Secret: "secret", APIBase: APIBaseSandBox})
+
+	var configured []string
+	pool.OnNewClient(func(tenant string, c *Client) {
+		configured = append(configured, tenant)
+		if pool.Len() != 0 {
+			t.Errorf("expected the client to be configured before being added")
+		}
+		c.SetRetryPolicy(RetryPolicy{MaxAttempts: 2})
+	})
+
+	if _, err := pool.Client(context.Background(), "a"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := pool.Client(context.Background(), "a"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(configured) != 1 || configured[0] != "a" {
+		t.Errorf("expected the client to be configured once, got %v", configured)
+	}
+}
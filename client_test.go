@@ -0,0 +1,194 @@
+package paypal
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSendWithAuth_CollapsesConcurrentTokenRequests(t *testing.T) {
+	var tokenRequests atomic.Int32
+	release := make(chan struct{})
+	server := newTokenTestServer(t, &tokenRequests, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"O-1"}`))
+	})
+	server.Config.Handler = slowTokenHandler(server.Config.Handler, release)
+
+	c, err := NewClient("clientID", "secret", server.URL)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	// A cancelled caller must not fail the refresh for the others
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.GetOrder(cancelled, "O-1"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := c.GetOrder(context.Background(), "O-1")
+			errs <- err
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+	if tokenRequests.Load() != 1 {
+		t.Errorf("got %d token requests, wanted 1", tokenRequests.Load())
+	}
+}
+
+func TestSendWithAuth_RefreshesTokenInBackground(t *testing.T) {
+	var tokenRequests atomic.Int32
+	release := make(chan struct{})
+	var lastToken atomic.Value
+	server := newTokenTestServer(t, &tokenRequests, func(w http.ResponseWriter, r *http.Request) {
+		lastToken.Store(r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte(`{"id":"O-1"}`))
+	})
+	server.Config.Handler = slowTokenHandler(server.Config.Handler, release)
+
+	c, err := NewClient("clientID", "secret", server.URL)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	c.Token = &TokenResponse{Token: "old"}
+	c.tokenExpiresAt = time.Now().Add(RefreshTokenInBackgroundBeforeExpiresIn / 2)
+
+	// The token request is blocked, the still valid token is used meanwhile
+	if _, err := c.GetOrder(context.Background(), "O-1"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if lastToken.Load() != "Bearer old" {
+		t.Errorf("got %v, wanted the old token", lastToken.Load())
+	}
+
+	close(release)
+	deadline := time.Now().Add(time.Second)
+	for {
+		if _, err := c.GetOrder(context.Background(), "O-1"); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if lastToken.Load() == "Bearer token-1" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("the token was not refreshed in the background")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if tokenRequests.Load() != 1 {
+		t.Errorf("got %d token requests, wanted 1", tokenRequests.Load())
+	}
+}
+
+// newTestClient returns a client with a valid token calling handler, without retry policy
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	c, err := NewClient("clientID", "secret", server.URL)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	c.Token = &TokenResponse{Token: "dummy"}
+
+	return c
+}
+
+// slowTokenHandler holds token requests until release is closed
+func slowTokenHandler(next http.Handler, release chan struct{}) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/oauth2/token" {
+			<-release
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func TestUse_MiddlewareChain(t *testing.T) {
+	var attempts atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Trace") != "outer,inner" {
+			t.Errorf("unexpected X-Trace header %q", r.Header.Get("X-Trace"))
+		}
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"O-1"}`))
+	})
+
+	var calls []string
+	trace := func(name string) Middleware {
+		return func(next RoundTripFunc) RoundTripFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, name)
+				value := name
+				if v := req.Header.Get("X-Trace"); v != "" {
+					value = v + "," + name
+				}
+				req.Header.Set("X-Trace", value)
+				defer req.Header.Del("X-Trace")
+				return next(req)
+			}
+		}
+	}
+
+	var log strings.Builder
+	c.Use(LoggingMiddleware(&log), RetryMiddleware(RetryPolicy{MaxAttempts: 2}), trace("outer"), trace("inner"))
+
+	if _, err := c.GetOrder(context.Background(), "O-1"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if strings.Join(calls, " ") != "outer inner outer inner" {
+		t.Errorf("unexpected middleware calls %v", calls)
+	}
+	if !strings.HasPrefix(log.String(), "GET /v2/checkout/orders/O-1 200 ") || strings.Count(log.String(), "\n") != 1 {
+		t.Errorf("unexpected log %q", log.String())
+	}
+}
+
+func TestOperationName(t *testing.T) {
+	tests := map[string]string{
+		"POST /v1/oauth2/token":                                   "paypal.oauth2.token",
+		"GET /v2/checkout/orders/O-1":                             "paypal.orders.get",
+		"POST /v2/checkout/orders":                                "paypal.orders.create",
+		"POST /v2/checkout/orders/O-1/capture":                    "paypal.orders.capture",
+		"POST /v2/payments/captures/C-1/refund":                   "paypal.captures.refund",
+		"POST /v1/payments/payouts-item/I-1/cancel":               "paypal.payouts.items.cancel",
+		"GET /v1/billing/subscriptions/S-1/transactions":          "paypal.subscriptions.transactions.list",
+		"POST /v1/customer/disputes/D-1/accept-claim":             "paypal.disputes.accept_claim",
+		"GET /v1/identity/openidconnect/userinfo/":                "paypal.identity.userinfo",
+		"PUT /v1/risk/transaction-contexts/M-1/O-1":               "paypal.risk.transaction_contexts",
+		"GET /v1/customer/partners/P-1/merchant-integrations/M-1": "paypal.partners.merchant_integrations.get",
+		"GET /v9/unknown":                                         "paypal.request",
+	}
+
+	for request, expected := range tests {
+		method, path, _ := strings.Cut(request, " ")
+		if got := operationName(method, path); got != expected {
+			t.Errorf("%s: got %s, wanted %s", request, got, expected)
+		}
+	}
+}
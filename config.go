@@ -0,0 +1,242 @@
+package paypal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Environments of Config
+const (
+	EnvironmentSandbox = "sandbox"
+	EnvironmentLive    = "live"
+)
+
+// ErrEnvironmentMismatch is returned by NewClientFromConfig when the APIBase belongs to another environment
+// than the credentials, e.g. live credentials sent to the sandbox
+var ErrEnvironmentMismatch = errors.New("paypal: the API base does not match the environment of the credentials")
+
+type (
+	// Config describes a Client, it can be decoded from JSON or YAML, or read from the environment with ConfigFromEnv
+	Config struct {
+		ClientID string `json:"client_id" yaml:"client_id"`
+		Secret   string `json:"secret" yaml:"secret"`
+		// Environment the credentials belong to, EnvironmentSandbox or EnvironmentLive
+		Environment string `json:"environment" yaml:"environment"`
+		// APIBase overrides the API base of the environment, e.g. for a proxy
+		APIBase string `json:"api_base,omitempty" yaml:"api_base,omitempty"`
+		// Timeout of a single HTTP request, e.g. "30s"
+		Timeout Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+		// MaxRetries enables the DefaultRetryPolicy with at most MaxRetries retries
+		MaxRetries int `json:"max_retries,omitempty" yaml:"max_retries,omitempty"`
+		// PartnerAttributionID is sent as PayPal-Partner-Attribution-Id with every request
+		PartnerAttributionID string `json:"partner_attribution_id,omitempty" yaml:"partner_attribution_id,omitempty"`
+		// WebhookID is used by VerifyWebhookSignature when no webhook ID is passed
+		WebhookID string `json:"webhook_id,omitempty" yaml:"webhook_id,omitempty"`
+		// LogLevel enables structured logging to stderr: debug, info, warn or error
+		LogLevel string `json:"log_level,omitempty" yaml:"log_level,omitempty"`
+	}
+
+	// Duration is a time.Duration decoded from strings like "30s"
+	Duration time.Duration
+)
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (d *Duration) UnmarshalText(text []byte) error {
+	duration, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*d = Duration(duration)
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(d).String()), nil
+}
+
+// ConfigFromEnv reads the Config from the environment variables PAYPAL_CLIENT_ID, PAYPAL_SECRET,
+// PAYPAL_ENVIRONMENT, PAYPAL_API_BASE, PAYPAL_TIMEOUT, PAYPAL_MAX_RETRIES, PAYPAL_PARTNER_ATTRIBUTION_ID,
+// PAYPAL_WEBHOOK_ID and PAYPAL_LOG_LEVEL. The secret can also be read from the file named by PAYPAL_SECRET_FILE.
+func ConfigFromEnv() (Config, error) {
+	config := Config{
+		ClientID:             os.Getenv("PAYPAL_CLIENT_ID"),
+		Secret:               os.Getenv("PAYPAL_SECRET"),
+		Environment:          os.Getenv("PAYPAL_ENVIRONMENT"),
+		APIBase:              os.Getenv("PAYPAL_API_BASE"),
+		PartnerAttributionID: os.Getenv("PAYPAL_PARTNER_ATTRIBUTION_ID"),
+		WebhookID:            os.Getenv("PAYPAL_WEBHOOK_ID"),
+		LogLevel:             os.Getenv("PAYPAL_LOG_LEVEL"),
+	}
+
+	if path := os.Getenv("PAYPAL_SECRET_FILE"); path != "" && config.Secret == "" {
+		secret, err := os.ReadFile(path)
+		if err != nil {
+			return config, err
+		}
+		config.Secret = strings.TrimSpace(string(secret))
+	}
+	if timeout := os.Getenv("PAYPAL_TIMEOUT"); timeout != "" {
+		if err := config.Timeout.UnmarshalText([]byte(timeout)); err != nil {
+			return config, fmt.Errorf("paypal: invalid PAYPAL_TIMEOUT: %w", err)
+		}
+	}
+	if retries := os.Getenv("PAYPAL_MAX_RETRIES"); retries != "" {
+		maxRetries, err := strconv.Atoi(retries)
+		if err != nil {
+			return config, fmt.Errorf("paypal: invalid PAYPAL_MAX_RETRIES: %w", err)
+		}
+		config.MaxRetries = maxRetries
+	}
+
+	return config, nil
+}
+
+// LoadConfigFile reads a JSON Config file
+func LoadConfigFile(path string) (Config, error) {
+	config := Config{}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return config, err
+	}
+	err = json.Unmarshal(data, &config)
+	return config, err
+}
+
+// Validate checks the config is complete and its APIBase matches the environment of the credentials
+func (c Config) Validate() error {
+	if c.ClientID == "" || c.Secret == "" {
+		return errors.New("paypal: client ID and secret are required")
+	}
+
+	switch c.Environment {
+	case EnvironmentSandbox, EnvironmentLive:
+	default:
+		return fmt.Errorf("paypal: environment must be %q or %q, got %q", EnvironmentSandbox, EnvironmentLive, c.Environment)
+	}
+
+	if c.APIBase != "" {
+		environment, err := apiBaseEnvironment(c.APIBase)
+		if err != nil {
+			return err
+		}
+		if environment != "" && environment != c.Environment {
+			return fmt.Errorf("%w: %s credentials with %s", ErrEnvironmentMismatch, c.Environment, c.APIBase)
+		}
+	}
+
+	if c.MaxRetries < 0 {
+		return fmt.Errorf("paypal: max retries must not be negative, got %d", c.MaxRetries)
+	}
+
+	if _, err := parseLogLevel(c.LogLevel); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// apiBaseEnvironment returns the environment of a PayPal API host, "" for other hosts such as proxies
+func apiBaseEnvironment(apiBase string) (string, error) {
+	u, err := url.Parse(apiBase)
+	if err != nil || u.Hostname() == "" {
+		return "", fmt.Errorf("paypal: invalid API base %q", apiBase)
+	}
+
+	switch strings.ToLower(u.Hostname()) {
+	case "api-m.paypal.com", "api.paypal.com":
+		return EnvironmentLive, nil
+	case "api-m.sandbox.paypal.com", "api.sandbox.paypal.com":
+		return EnvironmentSandbox, nil
+	}
+	return "", nil
+}
+
+// apiBase returns the APIBase of the environment unless overridden
+func (c Config) apiBase() string {
+	switch {
+	case c.APIBase != "":
+		return c.APIBase
+	case c.Environment == EnvironmentLive:
+		return APIBaseLive
+	default:
+		return APIBaseSandBox
+	}
+}
+
+// NewClientFromConfig returns a Client configured by config, after validating it
+func NewClientFromConfig(config Config) (*Client, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	c, err := NewClient(config.ClientID, config.Secret, config.apiBase())
+	if err != nil {
+		return nil, err
+	}
+
+	c.SetHTTPClient(&http.Client{Timeout: time.Duration(config.Timeout)})
+	if config.MaxRetries > 0 {
+		policy := DefaultRetryPolicy()
+		policy.MaxAttempts = config.MaxRetries + 1
+		c.SetRetryPolicy(policy)
+	}
+	if config.PartnerAttributionID != "" {
+		c.Use(headerMiddleware("PayPal-Partner-Attribution-Id", config.PartnerAttributionID))
+	}
+	c.SetWebhookID(config.WebhookID)
+	if level, _ := parseLogLevel(config.LogLevel); level != nil {
+		c.SetLogger(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level})))
+	}
+
+	return c, nil
+}
+
+// NewClientFromEnv returns a Client configured by the environment variables, see ConfigFromEnv
+func NewClientFromEnv() (*Client, error) {
+	config, err := ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewClientFromConfig(config)
+}
+
+// SetWebhookID sets the webhook ID used by VerifyWebhookSignature when none is passed
+func (c *Client) SetWebhookID(webhookID string) {
+	c.webhookID = webhookID
+}
+
+// parseLogLevel returns nil if logging is disabled
+func parseLogLevel(level string) (slog.Leveler, error) {
+	if level == "" {
+		return nil, nil
+	}
+
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(level)); err != nil {
+		return nil, fmt.Errorf("paypal: invalid log level %q", level)
+	}
+	return l, nil
+}
+
+// headerMiddleware sets a header on every request which does not have it yet
+func headerMiddleware(name, value string) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get(name) == "" {
+				req.Header.Set(name, value)
+			}
+			return next(req)
+		}
+	}
+}
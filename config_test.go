@@ -0,0 +1,99 @@
+package paypal
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestNewClientFromConfig(t *testing.T) {
+	var config Config
+	err := json.Unmarshal([]byte(`{
+		"client_id": "clientID",
+		"secret": "secret",
+		"environment": "live",
+		"timeout": "15s",
+		"max_retries": 2,
+		"partner_attribution_id": "BN-CODE",
+		"webhook_id": "WH-1",
+		"log_level": "warn"
+	}`), &config)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	c, err := NewClientFromConfig(config)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if c.APIBase != APIBaseLive || c.Client.Timeout != 15*time.Second || c.webhookID != "WH-1" {
+		t.Errorf("unexpected client %+v", c)
+	}
+	if c.retryPolicy == nil || c.retryPolicy.MaxAttempts != 3 {
+		t.Errorf("expected 3 attempts, got %+v", c.retryPolicy)
+	}
+	if c.logger == nil {
+		t.Errorf("expected structured logging")
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	invalid := map[string]Config{
+		"live with sandbox":   {ClientID: "id", Secret: "secret", Environment: EnvironmentLive, APIBase: APIBaseSandBox},
+		"sandbox with live":   {ClientID: "id", Secret: "secret", Environment: EnvironmentSandbox, APIBase: APIBaseLive},
+		"missing environment": {ClientID: "id", Secret: "secret"},
+		"missing secret":      {ClientID: "id", Environment: EnvironmentSandbox},
+		"invalid log level":   {ClientID: "id", Secret: "secret", Environment: EnvironmentSandbox, LogLevel: "verbose"},
+		"sandbox with legacy": {ClientID: "id", Secret: "secret", Environment: EnvironmentSandbox, APIBase: "https://api.paypal.com"},
+		"sandbox with slash":  {ClientID: "id", Secret: "secret", Environment: EnvironmentSandbox, APIBase: "https://api-m.paypal.com/"},
+		"invalid API base":    {ClientID: "id", Secret: "secret", Environment: EnvironmentSandbox, APIBase: "api-m.paypal.com"},
+		"negative retries":    {ClientID: "id", Secret: "secret", Environment: EnvironmentSandbox, MaxRetries: -1},
+	}
+	for name, config := range invalid {
+		if _, err := NewClientFromConfig(config); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	for _, name := range []string{"live with sandbox", "sandbox with legacy", "sandbox with slash"} {
+		if _, err := NewClientFromConfig(invalid[name]); !errors.Is(err, ErrEnvironmentMismatch) {
+			t.Errorf("%s: expected environment mismatch, got %v", name, err)
+		}
+	}
+
+	// Proxies are not checked, a path mentioning the sandbox does not make a live host one
+	valid := []Config{
+		{ClientID: "id", Secret: "secret", Environment: EnvironmentLive, APIBase: "https://paypal-proxy.internal"},
+		{ClientID: "id", Secret: "secret", Environment: EnvironmentLive, APIBase: "https://proxy.example.com/sandbox-free"},
+		{ClientID: "id", Secret: "secret", Environment: EnvironmentSandbox, APIBase: "https://api.sandbox.paypal.com"},
+	}
+	for _, config := range valid {
+		if err := config.Validate(); err != nil {
+			t.Errorf("%s: expected no error, got %v", config.APIBase, err)
+		}
+	}
+}
+
+func TestNewClientFromEnv(t *testing.T) {
+	secretFile := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(secretFile, []byte("file-secret\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("PAYPAL_CLIENT_ID", "clientID")
+	t.Setenv("PAYPAL_SECRET", "")
+	t.Setenv("PAYPAL_SECRET_FILE", secretFile)
+	t.Setenv("PAYPAL_ENVIRONMENT", EnvironmentSandbox)
+	t.Setenv("PAYPAL_TIMEOUT", "5s")
+
+	c, err := NewClientFromEnv()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if c.Secret != "file-secret" || c.APIBase != APIBaseSandBox || c.Client.Timeout != 5*time.Second {
+		t.Errorf("unexpected client %+v", c)
+	}
+}
@@ -64,7 +64,7 @@ const (
 	SubscriptionStatusExpired         SubscriptionStatus = "EXPIRED"
 )
 
-//Doc: https://developer.paypal.com/docs/api/subscriptions/v1/#definition-transaction
+// Doc: https://developer.paypal.com/docs/api/subscriptions/v1/#definition-transaction
 type SubscriptionTransactionStatus string
 
 const (
@@ -105,14 +105,46 @@ const (
 
 type PayeePreferred string // Doc: https://developer.paypal.com/api/orders/v2/#definition-payment_method
 const (
-	PayeePreferredUnrestricted 						PayeePreferred = "UNRESTRICTED"
-	PayeePreferredImmediatePaymentRequired 					PayeePreferred = "IMMEDIATE_PAYMENT_REQUIRED"
+	PayeePreferredUnrestricted             PayeePreferred = "UNRESTRICTED"
+	PayeePreferredImmediatePaymentRequired PayeePreferred = "IMMEDIATE_PAYMENT_REQUIRED"
 )
 
 type StandardEntryClassCode string // Doc: https://developer.paypal.com/api/orders/v2/#definition-payment_method
 const (
-	StandardEntryClassCodeTel					StandardEntryClassCode="TEL"
-	StandardEntryClassCodeWeb					StandardEntryClassCode="WEB"
-	StandardEntryClassCodeCcd					StandardEntryClassCode="CCD"
-	StandardEntryClassCodePpd					StandardEntryClassCode="PPD"
+	StandardEntryClassCodeTel StandardEntryClassCode = "TEL"
+	StandardEntryClassCodeWeb StandardEntryClassCode = "WEB"
+	StandardEntryClassCodeCcd StandardEntryClassCode = "CCD"
+	StandardEntryClassCodePpd StandardEntryClassCode = "PPD"
+)
+
+type Carrier string // Doc: https://developer.paypal.com/docs/tracking/reference/carriers/
+const (
+	CarrierDHL          Carrier = "DHL"
+	CarrierDPD          Carrier = "DPD"
+	CarrierFedEx        Carrier = "FEDEX"
+	CarrierTNT          Carrier = "TNT"
+	CarrierUPS          Carrier = "UPS"
+	CarrierUSPS         Carrier = "USPS"
+	CarrierRoyalMail    Carrier = "ROYAL_MAIL"
+	CarrierCanadaPost   Carrier = "CA_CANADA_POST"
+	CarrierDeutschePost Carrier = "DE_DEUTSCHE"
+	// CarrierOther requires the name of the carrier in CarrierNameOther
+	CarrierOther Carrier = "OTHER"
+)
+
+type OrderTrackerStatus string // Doc: https://developer.paypal.com/docs/api/orders/v2/#definition-order_tracker_response
+const (
+	OrderTrackerStatusShipped   OrderTrackerStatus = "SHIPPED"
+	OrderTrackerStatusCancelled OrderTrackerStatus = "CANCELLED"
+)
+
+type TrackerStatus string // Doc: https://developer.paypal.com/docs/tracking/reference/shipping-status/
+const (
+	TrackerStatusShipped     TrackerStatus = "SHIPPED"
+	TrackerStatusOnHold      TrackerStatus = "ON_HOLD"
+	TrackerStatusDelivered   TrackerStatus = "DELIVERED"
+	TrackerStatusCancelled   TrackerStatus = "CANCELLED"
+	TrackerStatusLocalPickup TrackerStatus = "LOCAL_PICKUP"
+	TrackerStatusInTransit   TrackerStatus = "IN_TRANSIT"
+	TrackerStatusReturned    TrackerStatus = "RETURNED"
 )
@@ -11,7 +11,9 @@ import (
 // ListDisputes - Lists disputes with a summary set of details.
 // Endpoint: GET /v1/customer/disputes
 // https://developer.paypal.com/docs/api/customer-disputes/v1/#disputes_list
-func (c *Client) ListDisputes(ctx context.Context, req *ListDisputesRequest) (*ListDisputesResponse, error) {
+func (c *Client) ListDisputes(ctx context.Context, req *ListDisputesRequest, opts ...CallOption) (*ListDisputesResponse, error) {
+	ctx = withCallOptions(ctx, opts)
+
 	response := &ListDisputesResponse{}
 
 	r, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s/v1/customer/disputes", c.APIBase), nil)
@@ -55,7 +57,9 @@ func (c *Client) ListDisputes(ctx context.Context, req *ListDisputesRequest) (*L
 // Shows details for a dispute, by ID
 // Endpoint: GET /v1/customer/disputes/{dispute_id}
 // https://developer.paypal.com/docs/api/customer-disputes/v1/#disputes_get
-func (c *Client) GetDisputeDetail(ctx context.Context, DisputeID string) (*GetDisputeDetailResponse, error) {
+func (c *Client) GetDisputeDetail(ctx context.Context, DisputeID string, opts ...CallOption) (*GetDisputeDetailResponse, error) {
+	ctx = withCallOptions(ctx, opts)
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/v1/customer/disputes/%s", c.APIBase, DisputeID), nil)
 	response := &GetDisputeDetailResponse{}
 	if err != nil {
@@ -70,7 +74,9 @@ func (c *Client) GetDisputeDetail(ctx context.Context, DisputeID string) (*GetDi
 // Partially updates a dispute, by ID
 // Endpoint: PATCH /v1/customer/disputes/{id}
 // https://developer.paypal.com/docs/api/customer-disputes/v1/#disputes_patch
-func (c *Client) UpdateDispute(ctx context.Context, disputeId string, params *UpdateDisputeParams) error {
+func (c *Client) UpdateDispute(ctx context.Context, disputeId string, params *UpdateDisputeParams, opts ...CallOption) error {
+	ctx = withCallOptions(ctx, opts)
+
 	req, err := c.NewRequest(ctx, http.MethodPatch, fmt.Sprintf("%s/v1/customer/disputes/%s", c.APIBase, disputeId), params)
 	if err != nil {
 		return err
@@ -81,7 +87,9 @@ func (c *Client) UpdateDispute(ctx context.Context, disputeId string, params *Up
 // Provides evidence for a dispute, by ID
 // Endpoint: POST /v1/customer/disputes/{id}/provide-evidence
 // https://developer.paypal.com/docs/api/customer-disputes/v1/#disputes_provide-evidence
-func (c *Client) DisputeProvideEvidence(ctx context.Context, disputeId string, params *DisputeProvideEvidenceParams) error {
+func (c *Client) DisputeProvideEvidence(ctx context.Context, disputeId string, params *DisputeProvideEvidenceParams, opts ...CallOption) error {
+	ctx = withCallOptions(ctx, opts)
+
 	req, err := c.NewRequest(ctx, http.MethodPost, fmt.Sprintf("%s/v1/customer/disputes/%s/provide-evidence", c.APIBase, disputeId), params)
 	if err != nil {
 		return err
@@ -92,7 +100,9 @@ func (c *Client) DisputeProvideEvidence(ctx context.Context, disputeId string, p
 // Appeals a dispute, by ID
 // Endpoint: POST /v1/customer/disputes/{id}/appeal
 // https://developer.paypal.com/docs/api/customer-disputes/v1/#disputes_appeal
-func (c *Client) DisputeAppeal(ctx context.Context, disputeId string, params *DisputeAppealParams) error {
+func (c *Client) DisputeAppeal(ctx context.Context, disputeId string, params *DisputeAppealParams, opts ...CallOption) error {
+	ctx = withCallOptions(ctx, opts)
+
 	req, err := c.NewRequest(ctx, http.MethodPost, fmt.Sprintf("%s/v1/customer/disputes/%s/appeal", c.APIBase, disputeId), params)
 	if err != nil {
 		return err
@@ -103,7 +113,9 @@ func (c *Client) DisputeAppeal(ctx context.Context, disputeId string, params *Di
 // Accepts liability for a claim, by ID
 // Endpoint: POST /v1/customer/disputes/{id}/accept-claim
 // https://developer.paypal.com/docs/api/customer-disputes/v1/#disputes_accept-claim
-func (c *Client) DisputeAcceptClaim(ctx context.Context, disputeId string, params *DisputeAcceptClaimParams) error {
+func (c *Client) DisputeAcceptClaim(ctx context.Context, disputeId string, params *DisputeAcceptClaimParams, opts ...CallOption) error {
+	ctx = withCallOptions(ctx, opts)
+
 	req, err := c.NewRequest(ctx, http.MethodPost, fmt.Sprintf("%s/v1/customer/disputes/%s/accept-claim", c.APIBase, disputeId), params)
 	if err != nil {
 		return err
@@ -114,7 +126,9 @@ func (c *Client) DisputeAcceptClaim(ctx context.Context, disputeId string, param
 // Settles a dispute in either the customer's or merchant's favor.
 // Endpoint: POST /v1/customer/disputes/{id}/adjudicate
 // https://developer.paypal.com/docs/api/customer-disputes/v1/#disputes_adjudicate
-func (c *Client) SettleDispute(ctx context.Context, disputeId string, adjudicateOutcome AdjudicationOutcome) error {
+func (c *Client) SettleDispute(ctx context.Context, disputeId string, adjudicateOutcome AdjudicationOutcome, opts ...CallOption) error {
+	ctx = withCallOptions(ctx, opts)
+
 	req, err := c.NewRequest(ctx, http.MethodPost, fmt.Sprintf("%s/v1/customer/disputes/%s/adjudicate", c.APIBase, disputeId), map[string]string{"adjudication_outcome": string(adjudicateOutcome)})
 	if err != nil {
 		return err
@@ -126,7 +140,9 @@ func (c *Client) SettleDispute(ctx context.Context, disputeId string, adjudicate
 // Endpoint: POST /v1/customer/disputes/{id}/require-evidence
 // https://developer.paypal.com/docs/api/customer-disputes/v1/#disputes_require-evidence
 // Important: This method is for sandbox use only.
-func (c *Client) DisputeUpdateStatus(ctx context.Context, disputeId string, adjudicateOutcome AdjudicationOutcome) error {
+func (c *Client) DisputeUpdateStatus(ctx context.Context, disputeId string, adjudicateOutcome AdjudicationOutcome, opts ...CallOption) error {
+	ctx = withCallOptions(ctx, opts)
+
 	req, err := c.NewRequest(ctx, http.MethodPost, fmt.Sprintf("%s/v1/customer/disputes/%s/require-evidence", c.APIBase, disputeId), map[string]string{"action": string(adjudicateOutcome)})
 	if err != nil {
 		return err
@@ -137,7 +153,9 @@ func (c *Client) DisputeUpdateStatus(ctx context.Context, disputeId string, adju
 // Escalates the dispute, by ID
 // Endpoint: POST /v1/customer/disputes/{id}/escalate
 // https://developer.paypal.com/docs/api/customer-disputes/v1/#disputes_escalate
-func (c *Client) DisputeEscalateToClaim(ctx context.Context, disputeId string, note string) error {
+func (c *Client) DisputeEscalateToClaim(ctx context.Context, disputeId string, note string, opts ...CallOption) error {
+	ctx = withCallOptions(ctx, opts)
+
 	req, err := c.NewRequest(ctx, http.MethodPost, fmt.Sprintf("%s/v1/customer/disputes/%s/escalate", c.APIBase, disputeId), map[string]string{"note": note})
 	if err != nil {
 		return err
@@ -148,7 +166,9 @@ func (c *Client) DisputeEscalateToClaim(ctx context.Context, disputeId string, n
 // Sends a message about a dispute, by ID, to the other party in the dispute.
 // Endpoint: POST /v1/customer/disputes/{id}/send-message
 // https://developer.paypal.com/docs/api/customer-disputes/v1/#disputes_send-message
-func (c *Client) DisputeSendMessageToOtherParty(ctx context.Context, disputeId string, message string) error {
+func (c *Client) DisputeSendMessageToOtherParty(ctx context.Context, disputeId string, message string, opts ...CallOption) error {
+	ctx = withCallOptions(ctx, opts)
+
 	req, err := c.NewRequest(ctx, http.MethodPost, fmt.Sprintf("%s/v1/customer/disputes/%s/send-message", c.APIBase, disputeId), map[string]string{"message": message})
 	if err != nil {
 		return err
@@ -159,7 +179,9 @@ func (c *Client) DisputeSendMessageToOtherParty(ctx context.Context, disputeId s
 // Makes an offer to the other party to resolve a dispute, by ID
 // Endpoint: POST /v1/customer/disputes/{id}/make-offer
 // https://developer.paypal.com/docs/api/customer-disputes/v1/#disputes_make-offer
-func (c *Client) DisputeMakeOffer(ctx context.Context, disputeId string, params *DisputeMakeOfferParams) error {
+func (c *Client) DisputeMakeOffer(ctx context.Context, disputeId string, params *DisputeMakeOfferParams, opts ...CallOption) error {
+	ctx = withCallOptions(ctx, opts)
+
 	req, err := c.NewRequest(ctx, http.MethodPost, fmt.Sprintf("%s/v1/customer/disputes/%s/make-offer", c.APIBase, disputeId), params)
 	if err != nil {
 		return err
@@ -170,7 +192,9 @@ func (c *Client) DisputeMakeOffer(ctx context.Context, disputeId string, params
 // The customer accepts the offer from merchant to resolve a dispute, by ID
 // Endpoint: POST /v1/customer/disputes/{id}/accept-offer
 // https://developer.paypal.com/docs/api/customer-disputes/v1/#disputes_accept-offer
-func (c *Client) DisputeAcceptOffer(ctx context.Context, disputeId string, note string) error {
+func (c *Client) DisputeAcceptOffer(ctx context.Context, disputeId string, note string, opts ...CallOption) error {
+	ctx = withCallOptions(ctx, opts)
+
 	req, err := c.NewRequest(ctx, http.MethodPost, fmt.Sprintf("%s/v1/customer/disputes/%s/accept-offer", c.APIBase, disputeId), map[string]string{"note": note})
 	if err != nil {
 		return err
@@ -181,7 +205,9 @@ func (c *Client) DisputeAcceptOffer(ctx context.Context, disputeId string, note
 // Denies an offer that the merchant proposes for a dispute, by ID.
 // Endpoint: POST /v1/customer/disputes/{id}/deny-offer
 // https://developer.paypal.com/docs/api/customer-disputes/v1/#disputes_deny-offer
-func (c *Client) DisputeDenyOffer(ctx context.Context, disputeId string, note string) error {
+func (c *Client) DisputeDenyOffer(ctx context.Context, disputeId string, note string, opts ...CallOption) error {
+	ctx = withCallOptions(ctx, opts)
+
 	req, err := c.NewRequest(ctx, http.MethodPost, fmt.Sprintf("%s/v1/customer/disputes/%s/deny-offer", c.APIBase, disputeId), map[string]string{"note": note})
 	if err != nil {
 		return err
@@ -192,7 +218,9 @@ func (c *Client) DisputeDenyOffer(ctx context.Context, disputeId string, note st
 // Acknowledges that the customer returned an item for a dispute, by ID.
 // Endpoint: POST /v1/customer/disputes/{id}/acknowledge-return-item
 // https://developer.paypal.com/docs/api/customer-disputes/v1/#disputes_acknowledge-return-item
-func (c *Client) DisputeAcknowledgeReturnItem(ctx context.Context, disputeId string, params *DisputeAcknowledgeReturnItemParams) error {
+func (c *Client) DisputeAcknowledgeReturnItem(ctx context.Context, disputeId string, params *DisputeAcknowledgeReturnItemParams, opts ...CallOption) error {
+	ctx = withCallOptions(ctx, opts)
+
 	req, err := c.NewRequest(ctx, http.MethodPost, fmt.Sprintf("%s/v1/customer/disputes/%s/acknowledge-return-item", c.APIBase, disputeId), params)
 	if err != nil {
 		return err
@@ -203,7 +231,9 @@ func (c *Client) DisputeAcknowledgeReturnItem(ctx context.Context, disputeId str
 // Provides supporting information for a dispute, by ID.
 // Endpoint: POST /v1/customer/disputes/{id}/provide-supporting-info
 // https://developer.paypal.com/docs/api/customer-disputes/v1/#disputes_provide-supporting-info
-func (c *Client) DisputeProvideSupportingInfo(ctx context.Context, disputeId string, notes string) error {
+func (c *Client) DisputeProvideSupportingInfo(ctx context.Context, disputeId string, notes string, opts ...CallOption) error {
+	ctx = withCallOptions(ctx, opts)
+
 	req, err := c.NewRequest(ctx, http.MethodPost, fmt.Sprintf("%s/v1/customer/disputes/%s/provide-supporting-info", c.APIBase, disputeId), map[string]string{"notes": notes})
 	if err != nil {
 		return err
@@ -0,0 +1,289 @@
+package paypal
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// ErrorCategory groups PayPal errors by the way they should be handled.
+// Categories are errors themselves, so errors.Is(err, paypal.ErrorCategoryDeclined) works
+// for any error returned by Send.
+type ErrorCategory string
+
+// Possible values of ErrorCategory
+const (
+	ErrorCategoryUnknown     ErrorCategory = ""
+	ErrorCategoryValidation  ErrorCategory = "VALIDATION"
+	ErrorCategoryAuth        ErrorCategory = "AUTH"
+	ErrorCategoryNotFound    ErrorCategory = "NOT_FOUND"
+	ErrorCategoryDeclined    ErrorCategory = "DECLINED"
+	ErrorCategoryConflict    ErrorCategory = "CONFLICT"
+	ErrorCategoryRateLimited ErrorCategory = "RATE_LIMITED"
+	ErrorCategoryServer      ErrorCategory = "SERVER"
+)
+
+// Error implements error
+func (c ErrorCategory) Error() string {
+	if c == ErrorCategoryUnknown {
+		return "paypal: unknown error"
+	}
+	return "paypal: " + strings.ReplaceAll(strings.ToLower(string(c)), "_", " ") + " error"
+}
+
+// IssueError is a sentinel error matching PayPal errors which report the Issue code,
+// either in the details of the error or as its name
+//
+// https://developer.paypal.com/api/rest/reference/orders/v2/errors/
+type IssueError struct {
+	Issue    string
+	Category ErrorCategory
+}
+
+// Error implements error
+func (e *IssueError) Error() string {
+	return "paypal: " + e.Issue
+}
+
+// Is makes an IssueError match its category
+func (e *IssueError) Is(target error) bool {
+	category, ok := target.(ErrorCategory)
+	return ok && category == e.Category
+}
+
+// issueErrors indexes all known issue codes
+var issueErrors = map[string]*IssueError{}
+
+func newIssueError(issue string, category ErrorCategory) *IssueError {
+	e := &IssueError{Issue: issue, Category: category}
+	issueErrors[issue] = e
+	return e
+}
+
+// Common errors
+var (
+	ErrInvalidRequest            = newIssueError("INVALID_REQUEST", ErrorCategoryValidation)
+	ErrInvalidParameterValue     = newIssueError("INVALID_PARAMETER_VALUE", ErrorCategoryValidation)
+	ErrMissingRequiredParameter  = newIssueError("MISSING_REQUIRED_PARAMETER", ErrorCategoryValidation)
+	ErrInvalidStringLength       = newIssueError("INVALID_STRING_LENGTH", ErrorCategoryValidation)
+	ErrMalformedRequestJSON      = newIssueError("MALFORMED_REQUEST_JSON", ErrorCategoryValidation)
+	ErrUnprocessableEntity       = newIssueError("UNPROCESSABLE_ENTITY", ErrorCategoryValidation)
+	ErrCurrencyNotSupported      = newIssueError("CURRENCY_NOT_SUPPORTED", ErrorCategoryValidation)
+	ErrAuthenticationFailure     = newIssueError("AUTHENTICATION_FAILURE", ErrorCategoryAuth)
+	ErrNotAuthorized             = newIssueError("NOT_AUTHORIZED", ErrorCategoryAuth)
+	ErrPermissionDenied          = newIssueError("PERMISSION_DENIED", ErrorCategoryAuth)
+	ErrInvalidResourceID         = newIssueError("INVALID_RESOURCE_ID", ErrorCategoryNotFound)
+	ErrResourceNotFound          = newIssueError("RESOURCE_NOT_FOUND", ErrorCategoryNotFound)
+	ErrDuplicateRequestID        = newIssueError("DUPLICATE_REQUEST_ID", ErrorCategoryConflict)
+	ErrPreviousRequestInProgress = newIssueError("PREVIOUS_REQUEST_IN_PROGRESS", ErrorCategoryConflict)
+	ErrRateLimitReached          = newIssueError("RATE_LIMIT_REACHED", ErrorCategoryRateLimited)
+	ErrInternalServerError       = newIssueError("INTERNAL_SERVER_ERROR", ErrorCategoryServer)
+	ErrInternalServiceError      = newIssueError("INTERNAL_SERVICE_ERROR", ErrorCategoryServer)
+	ErrServiceUnavailable        = newIssueError("SERVICE_UNAVAILABLE", ErrorCategoryServer)
+)
+
+// Orders errors
+//
+// https://developer.paypal.com/api/rest/reference/orders/v2/errors/
+var (
+	ErrInstrumentDeclined               = newIssueError("INSTRUMENT_DECLINED", ErrorCategoryDeclined)
+	ErrPayerActionRequired              = newIssueError("PAYER_ACTION_REQUIRED", ErrorCategoryDeclined)
+	ErrPayerCannotPay                   = newIssueError("PAYER_CANNOT_PAY", ErrorCategoryDeclined)
+	ErrTransactionRefused               = newIssueError("TRANSACTION_REFUSED", ErrorCategoryDeclined)
+	ErrCardExpired                      = newIssueError("CARD_EXPIRED", ErrorCategoryDeclined)
+	ErrMaxNumberOfPaymentAttempts       = newIssueError("MAX_NUMBER_OF_PAYMENT_ATTEMPTS_EXCEEDED", ErrorCategoryDeclined)
+	ErrPayeeAccountRestricted           = newIssueError("PAYEE_ACCOUNT_RESTRICTED", ErrorCategoryDeclined)
+	ErrOrderAlreadyCaptured             = newIssueError("ORDER_ALREADY_CAPTURED", ErrorCategoryConflict)
+	ErrOrderAlreadyAuthorized           = newIssueError("ORDER_ALREADY_AUTHORIZED", ErrorCategoryConflict)
+	ErrOrderNotApproved                 = newIssueError("ORDER_NOT_APPROVED", ErrorCategoryConflict)
+	ErrOrderExpired                     = newIssueError("ORDER_EXPIRED", ErrorCategoryConflict)
+	ErrOrderCompletedOrVoided           = newIssueError("ORDER_COMPLETED_OR_VOIDED", ErrorCategoryConflict)
+	ErrDuplicateInvoiceID               = newIssueError("DUPLICATE_INVOICE_ID", ErrorCategoryConflict)
+	ErrAmountMismatch                   = newIssueError("AMOUNT_MISMATCH", ErrorCategoryValidation)
+	ErrItemTotalMismatch                = newIssueError("ITEM_TOTAL_MISMATCH", ErrorCategoryValidation)
+	ErrPayeeNotEnabledForCardProcessing = newIssueError("PAYEE_NOT_ENABLED_FOR_CARD_PROCESSING", ErrorCategoryValidation)
+)
+
+// Payments errors
+//
+// https://developer.paypal.com/api/rest/reference/payments/v2/errors/
+var (
+	ErrAuthorizationAlreadyCaptured = newIssueError("AUTHORIZATION_ALREADY_CAPTURED", ErrorCategoryConflict)
+	ErrAuthorizationExpired         = newIssueError("AUTHORIZATION_EXPIRED", ErrorCategoryConflict)
+	ErrAuthorizationVoided          = newIssueError("AUTHORIZATION_VOIDED", ErrorCategoryConflict)
+	ErrMaxCaptureCountExceeded      = newIssueError("MAX_CAPTURE_COUNT_EXCEEDED", ErrorCategoryConflict)
+	ErrCaptureFullyRefunded         = newIssueError("CAPTURE_FULLY_REFUNDED", ErrorCategoryConflict)
+	ErrRefundAmountExceeded         = newIssueError("REFUND_AMOUNT_EXCEEDED", ErrorCategoryConflict)
+	ErrRefundTimeLimitExceeded      = newIssueError("REFUND_TIME_LIMIT_EXCEEDED", ErrorCategoryConflict)
+	ErrRefundNotAllowed             = newIssueError("REFUND_NOT_ALLOWED", ErrorCategoryConflict)
+	ErrPendingCapture               = newIssueError("PENDING_CAPTURE", ErrorCategoryConflict)
+	ErrInsufficientFunds            = newIssueError("INSUFFICIENT_FUNDS", ErrorCategoryDeclined)
+)
+
+// Payouts errors
+//
+// https://developer.paypal.com/docs/payouts/standard/reference/error-messages/
+var (
+	ErrReceiverUnregistered   = newIssueError("RECEIVER_UNREGISTERED", ErrorCategoryDeclined)
+	ErrReceiverAccountLocked  = newIssueError("RECEIVER_ACCOUNT_LOCKED", ErrorCategoryDeclined)
+	ErrCurrencyCompliance     = newIssueError("CURRENCY_COMPLIANCE", ErrorCategoryDeclined)
+	ErrUserBusinessError      = newIssueError("USER_BUSINESS_ERROR", ErrorCategoryConflict)
+	ErrItemAlreadyCancelled   = newIssueError("ITEM_ALREADY_CANCELLED", ErrorCategoryConflict)
+	ErrItemCancellationDenied = newIssueError("ITEM_CANCELLATION_DENIED", ErrorCategoryConflict)
+	ErrPayoutValidationError  = newIssueError("VALIDATION_ERROR", ErrorCategoryValidation)
+)
+
+// Subscriptions errors
+//
+// https://developer.paypal.com/docs/api/subscriptions/v1/#errors
+var (
+	ErrSubscriptionStatusInvalid = newIssueError("SUBSCRIPTION_STATUS_INVALID", ErrorCategoryConflict)
+	ErrPlanStatusInvalid         = newIssueError("PLAN_STATUS_INVALID", ErrorCategoryConflict)
+	ErrZeroOutstandingBalance    = newIssueError("ZERO_OUTSTANDING_BALANCE", ErrorCategoryConflict)
+)
+
+// Issues returns the issue codes reported by PayPal, from the details first and then the error name
+func (r *ErrorResponse) Issues() []string {
+	issues := make([]string, 0, len(r.Details)+1)
+	for _, detail := range r.Details {
+		if detail.Issue != "" {
+			issues = append(issues, detail.Issue)
+		}
+	}
+	if r.Name != "" {
+		issues = append(issues, r.Name)
+	}
+
+	return issues
+}
+
+// Issue returns the first known issue code of the error, or the first reported one if none is known
+func (r *ErrorResponse) Issue() string {
+	issues := r.Issues()
+	for _, issue := range issues {
+		if _, ok := issueErrors[issue]; ok {
+			return issue
+		}
+	}
+	if len(issues) > 0 {
+		return issues[0]
+	}
+
+	return ""
+}
+
+// Category returns the category of the first known issue code,
+// falling back to the category of the HTTP status code
+func (r *ErrorResponse) Category() ErrorCategory {
+	if e, ok := issueErrors[r.Issue()]; ok {
+		return e.Category
+	}
+	if r.Response == nil {
+		return ErrorCategoryUnknown
+	}
+
+	switch code := r.Response.StatusCode; {
+	case code == http.StatusBadRequest || code == http.StatusUnprocessableEntity:
+		return ErrorCategoryValidation
+	case code == http.StatusUnauthorized || code == http.StatusForbidden:
+		return ErrorCategoryAuth
+	case code == http.StatusNotFound:
+		return ErrorCategoryNotFound
+	case code == http.StatusConflict:
+		return ErrorCategoryConflict
+	case code == http.StatusTooManyRequests:
+		return ErrorCategoryRateLimited
+	case code >= http.StatusInternalServerError:
+		return ErrorCategoryServer
+	}
+
+	return ErrorCategoryUnknown
+}
+
+// Is makes errors.Is match an ErrorResponse against the IssueError sentinels and error categories
+func (r *ErrorResponse) Is(target error) bool {
+	switch t := target.(type) {
+	case *IssueError:
+		for _, issue := range r.Issues() {
+			if issue == t.Issue {
+				return true
+			}
+		}
+	case ErrorCategory:
+		return t != ErrorCategoryUnknown && r.Category() == t
+	}
+
+	return false
+}
+
+// IsValidation reports whether err is a PayPal validation error
+func IsValidation(err error) bool {
+	return errors.Is(err, ErrorCategoryValidation)
+}
+
+// IsAuth reports whether err is a PayPal authentication or authorization error
+func IsAuth(err error) bool {
+	return errors.Is(err, ErrorCategoryAuth)
+}
+
+// IsNotFound reports whether err is a PayPal error for a missing resource
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrorCategoryNotFound)
+}
+
+// IsDeclined reports whether err is a PayPal error for a declined payment
+func IsDeclined(err error) bool {
+	return errors.Is(err, ErrorCategoryDeclined)
+}
+
+// IsConflict reports whether err is a PayPal error for a resource in a wrong state
+func IsConflict(err error) bool {
+	return errors.Is(err, ErrorCategoryConflict)
+}
+
+// IsRateLimited reports whether err is a PayPal error for a throttled request
+func IsRateLimited(err error) bool {
+	return errors.Is(err, ErrorCategoryRateLimited)
+}
+
+// IsServer reports whether err is a PayPal internal error
+func IsServer(err error) bool {
+	return errors.Is(err, ErrorCategoryServer)
+}
+
+// IsTransient reports whether err is likely to go away by itself:
+// a network failure, a throttled request, a PayPal internal error,
+// or an idempotent request whose first attempt is still in progress
+func IsTransient(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var errResp *ErrorResponse
+	if errors.As(err, &errResp) {
+		return IsRateLimited(errResp) || IsServer(errResp) || errors.Is(errResp, ErrPreviousRequestInProgress)
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// IsRetryable reports whether the request which failed with err can be sent again:
+// the error is transient and the request is idempotent, i.e. its method is idempotent or it carries
+// a PayPal-Request-Id header. For network errors the request is unknown and its idempotency
+// must be checked by the caller.
+func IsRetryable(err error) bool {
+	if !IsTransient(err) {
+		return false
+	}
+
+	var errResp *ErrorResponse
+	if errors.As(err, &errResp) && errResp.Response != nil && errResp.Response.Request != nil {
+		return isIdempotentRequest(errResp.Response.Request)
+	}
+
+	return true
+}
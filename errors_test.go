@@ -0,0 +1,85 @@
+package paypal
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestErrorResponse_Is(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"name":"UNPROCESSABLE_ENTITY","debug_id":"abc","details":[{"issue":"INSTRUMENT_DECLINED"}]}`))
+	}))
+	defer server.Close()
+
+	c, err := NewClient("clientID", "secret", server.URL)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	c.Token = &TokenResponse{Token: "dummy"}
+
+	_, err = c.CaptureOrder(context.Background(), "O-1", CaptureOrderRequest{})
+	if !errors.Is(err, ErrInstrumentDeclined) {
+		t.Errorf("expected ErrInstrumentDeclined, got %v", err)
+	}
+	if !errors.Is(err, ErrUnprocessableEntity) {
+		t.Errorf("expected ErrUnprocessableEntity, got %v", err)
+	}
+	if errors.Is(err, ErrOrderAlreadyCaptured) {
+		t.Error("unexpected ErrOrderAlreadyCaptured")
+	}
+	if !IsDeclined(err) || IsValidation(err) {
+		t.Errorf("expected declined category, got %v", err)
+	}
+	if IsTransient(err) || IsRetryable(err) {
+		t.Error("declined payment must not be transient")
+	}
+}
+
+func TestErrorResponse_Category(t *testing.T) {
+	tests := []struct {
+		status   int
+		name     string
+		expected ErrorCategory
+	}{
+		{http.StatusConflict, "", ErrorCategoryConflict},
+		{http.StatusTooManyRequests, "RATE_LIMIT_REACHED", ErrorCategoryRateLimited},
+		{http.StatusServiceUnavailable, "", ErrorCategoryServer},
+		{http.StatusForbidden, "NOT_AUTHORIZED", ErrorCategoryAuth},
+		{http.StatusNotFound, "SOMETHING_NEW", ErrorCategoryNotFound},
+		{http.StatusBadRequest, "DUPLICATE_INVOICE_ID", ErrorCategoryConflict},
+	}
+
+	for _, tt := range tests {
+		r := &ErrorResponse{Name: tt.name, Response: &http.Response{StatusCode: tt.status}}
+		if got := r.Category(); got != tt.expected {
+			t.Errorf("status %d name %q: got category %q, wanted %q", tt.status, tt.name, got, tt.expected)
+		}
+		if !errors.Is(r, tt.expected) {
+			t.Errorf("status %d name %q: expected errors.Is to match %q", tt.status, tt.name, tt.expected)
+		}
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	post, _ := http.NewRequest(http.MethodPost, "https://api-m.paypal.com/v1/payments/payouts", nil)
+	unavailable := &ErrorResponse{Response: &http.Response{StatusCode: http.StatusServiceUnavailable, Request: post}}
+
+	if !IsTransient(unavailable) {
+		t.Error("expected 503 to be transient")
+	}
+	if IsRetryable(unavailable) {
+		t.Error("POST without PayPal-Request-Id must not be retryable")
+	}
+
+	post.Header.Set("PayPal-Request-Id", "request-1")
+	if !IsRetryable(unavailable) {
+		t.Error("POST with PayPal-Request-Id must be retryable")
+	}
+	if IsRetryable(context.Canceled) {
+		t.Error("context cancellation must not be retryable")
+	}
+}
@@ -0,0 +1,430 @@
+package paypal
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// IssuerSandBox is the issuer of ID tokens of the sandbox
+	IssuerSandBox = "https://www.sandbox.paypal.com"
+
+	// IssuerLive is the issuer of ID tokens of the live environment
+	IssuerLive = "https://www.paypal.com"
+
+	// DefaultJWKSCacheTTL is how long RemoteJWKS keeps the keys before fetching them again
+	DefaultJWKSCacheTTL = time.Hour
+
+	// DefaultJWKSMinRefreshInterval is how long RemoteJWKS waits between two fetches for unknown keys
+	DefaultJWKSMinRefreshInterval = time.Minute
+
+	// idTokenLeeway tolerates clock skew when checking the expiration of ID tokens
+	idTokenLeeway = time.Minute
+
+	// minRSAKeyBits is the size under which RSA keys are refused
+	minRSAKeyBits = 2048
+)
+
+// ErrInvalidIDToken is returned by ValidateIDToken, wrapped with the reason the token was rejected
+var ErrInvalidIDToken = errors.New("paypal: invalid id token")
+
+type (
+	// JSONWebKey is a public key used by PayPal to sign ID tokens
+	JSONWebKey struct {
+		KeyType   string `json:"kty"`
+		KeyID     string `json:"kid,omitempty"`
+		Use       string `json:"use,omitempty"`
+		Algorithm string `json:"alg,omitempty"`
+		N         string `json:"n"`
+		E         string `json:"e"`
+	}
+
+	// JSONWebKeySet is the set of keys published by PayPal
+	JSONWebKeySet struct {
+		Keys []JSONWebKey `json:"keys"`
+	}
+
+	// JWKSSource provides the keys ID tokens are verified with
+	JWKSSource interface {
+		JWKS(ctx context.Context) (*JSONWebKeySet, error)
+	}
+
+	// StaticJWKS is a JWKSSource with a fixed set of keys, e.g. for tests
+	StaticJWKS JSONWebKeySet
+
+	// RemoteJWKS is a JWKSSource fetching the keys from URL and caching them for TTL
+	RemoteJWKS struct {
+		URL        string
+		HTTPClient *http.Client
+		// TTL is DefaultJWKSCacheTTL if not set
+		TTL time.Duration
+		// MinRefreshInterval is DefaultJWKSMinRefreshInterval if not set
+		MinRefreshInterval time.Duration
+
+		mu        sync.Mutex
+		keys      *JSONWebKeySet
+		fetchedAt time.Time
+	}
+
+	// IDTokenClaims are the claims of an ID token of Log in with PayPal.
+	// The profile claims are the same as the ones returned by GetUserInfo, see UserInfo.
+	IDTokenClaims struct {
+		Issuer        string
+		Subject       string
+		Audience      []string
+		ExpiresAt     time.Time
+		IssuedAt      time.Time
+		AuthTime      time.Time
+		Nonce         string
+		UserID        string
+		Name          string
+		GivenName     string
+		FamilyName    string
+		Email         string
+		EmailVerified bool
+		PayerID       string
+		Address       *Address
+	}
+
+	// idTokenPayload is the JSON payload of an ID token
+	idTokenPayload struct {
+		Issuer        string          `json:"iss"`
+		Subject       string          `json:"sub"`
+		Audience      json.RawMessage `json:"aud"`
+		ExpiresAt     json.Number     `json:"exp"`
+		IssuedAt      json.Number     `json:"iat"`
+		AuthTime      json.Number     `json:"auth_time"`
+		Nonce         string          `json:"nonce"`
+		UserID        string          `json:"user_id"`
+		Name          string          `json:"name"`
+		GivenName     string          `json:"given_name"`
+		FamilyName    string          `json:"family_name"`
+		Email         string          `json:"email"`
+		EmailVerified interface{}     `json:"email_verified"`
+		PayerID       string          `json:"payer_id"`
+		Address       *Address        `json:"address"`
+	}
+)
+
+// NewRemoteJWKS returns a RemoteJWKS fetching the keys from url
+func NewRemoteJWKS(url string, client *http.Client) *RemoteJWKS {
+	return &RemoteJWKS{URL: url, HTTPClient: client}
+}
+
+// JWKS implements JWKSSource
+func (s StaticJWKS) JWKS(ctx context.Context) (*JSONWebKeySet, error) {
+	keys := JSONWebKeySet(s)
+	return &keys, nil
+}
+
+// JWKS implements JWKSSource, the keys are fetched again once the TTL elapsed
+func (s *RemoteJWKS) JWKS(ctx context.Context) (*JSONWebKeySet, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ttl := s.TTL
+	if ttl <= 0 {
+		ttl = DefaultJWKSCacheTTL
+	}
+	if s.keys != nil && time.Since(s.fetchedAt) < ttl {
+		return s.keys, nil
+	}
+
+	return s.fetchLocked(ctx)
+}
+
+// Refresh fetches the keys regardless of the TTL, ValidateIDToken calls it for keys missing from the cache.
+// The cached keys are returned if they were fetched less than MinRefreshInterval ago,
+// so tokens signed with unknown keys don't trigger a fetch each.
+func (s *RemoteJWKS) Refresh(ctx context.Context) (*JSONWebKeySet, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	interval := s.MinRefreshInterval
+	if interval <= 0 {
+		interval = DefaultJWKSMinRefreshInterval
+	}
+	if s.keys != nil && time.Since(s.fetchedAt) < interval {
+		return s.keys, nil
+	}
+
+	return s.fetchLocked(ctx)
+}
+
+func (s *RemoteJWKS) fetchLocked(ctx context.Context) (*JSONWebKeySet, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", s.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	client := s.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("paypal: fetching JWKS from %s: unexpected status %d", s.URL, resp.StatusCode)
+	}
+
+	keys := &JSONWebKeySet{}
+	if err := json.NewDecoder(resp.Body).Decode(keys); err != nil {
+		return nil, err
+	}
+
+	s.keys = keys
+	s.fetchedAt = time.Now()
+	return keys, nil
+}
+
+// key returns the key with the ID, or the only key of the set if the token has no key ID
+func (s *JSONWebKeySet) key(kid string) *JSONWebKey {
+	for i, key := range s.Keys {
+		if key.KeyID == kid || (kid == "" && len(s.Keys) == 1) {
+			return &s.Keys[i]
+		}
+	}
+	return nil
+}
+
+// RSAPublicKey decodes the RSA public key, keys shorter than 2048 bits are refused
+func (k *JSONWebKey) RSAPublicKey() (*rsa.PublicKey, error) {
+	if k.KeyType != "RSA" {
+		return nil, fmt.Errorf("paypal: unsupported key type %q", k.KeyType)
+	}
+	n, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(k.N, "="))
+	if err != nil {
+		return nil, err
+	}
+	e, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(k.E, "="))
+	if err != nil {
+		return nil, err
+	}
+
+	exponent := new(big.Int).SetBytes(e)
+	if !exponent.IsInt64() || exponent.Int64() < 3 || exponent.Int64() > 1<<31-1 {
+		return nil, errors.New("paypal: invalid RSA exponent")
+	}
+	modulus := new(big.Int).SetBytes(n)
+	if modulus.BitLen() < minRSAKeyBits {
+		return nil, fmt.Errorf("paypal: RSA key of %d bits is too short", modulus.BitLen())
+	}
+	return &rsa.PublicKey{N: modulus, E: int(exponent.Int64())}, nil
+}
+
+// SetJWKSSource sets the keys ID tokens are verified with,
+// by default they are fetched from APIBase/v1/oauth2/certs and cached
+func (c *Client) SetJWKSSource(source JWKSSource) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.jwksSource = source
+}
+
+// jwks returns the JWKSSource of the client
+func (c *Client) jwks() JWKSSource {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.jwksSource == nil {
+		c.jwksSource = NewRemoteJWKS(c.APIBase+"/v1/oauth2/certs", c.Client)
+	}
+	return c.jwksSource
+}
+
+// ValidateIDToken verifies the RS256 signature of an ID token returned by ExchangeAuthorizationCode,
+// and checks it was issued by PayPal for the client, is not expired and carries the nonce
+// passed to AuthorizeURL. The nonce is required, see ValidateIDTokenWithoutNonce.
+// The returned error wraps ErrInvalidIDToken if the token is rejected.
+func (c *Client) ValidateIDToken(ctx context.Context, idToken string, nonce string) (*IDTokenClaims, error) {
+	if nonce == "" {
+		return nil, errors.New("paypal: ValidateIDToken requires the nonce passed to AuthorizeURL, see ValidateIDTokenWithoutNonce")
+	}
+
+	return c.validateIDToken(ctx, idToken, &nonce)
+}
+
+// ValidateIDTokenWithoutNonce is ValidateIDToken for flows which didn't pass a nonce to AuthorizeURL,
+// such tokens can be replayed so prefer sending a nonce
+func (c *Client) ValidateIDTokenWithoutNonce(ctx context.Context, idToken string) (*IDTokenClaims, error) {
+	return c.validateIDToken(ctx, idToken, nil)
+}
+
+// validateIDToken validates the token, the nonce is not checked if nil
+func (c *Client) validateIDToken(ctx context.Context, idToken string, nonce *string) (*IDTokenClaims, error) {
+	parts := strings.Split(idToken, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed token", ErrInvalidIDToken)
+	}
+
+	var header struct {
+		Algorithm string `json:"alg"`
+		KeyID     string `json:"kid"`
+	}
+	if err := decodeJWTPart(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+	if header.Algorithm != "RS256" {
+		return nil, fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidIDToken, header.Algorithm)
+	}
+
+	key, err := c.idTokenKey(ctx, header.KeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+		return nil, fmt.Errorf("%w: invalid signature", ErrInvalidIDToken)
+	}
+
+	payload := &idTokenPayload{}
+	if err := decodeJWTPart(parts[1], payload); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+	claims, err := payload.claims()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+
+	issuer := IssuerSandBox
+	if c.APIBase == APIBaseLive {
+		issuer = IssuerLive
+	}
+	switch {
+	case strings.TrimSuffix(claims.Issuer, "/") != issuer:
+		return nil, fmt.Errorf("%w: unexpected issuer %q", ErrInvalidIDToken, claims.Issuer)
+	case !slices.Contains(claims.Audience, c.ClientID):
+		return nil, fmt.Errorf("%w: token issued for another client", ErrInvalidIDToken)
+	case claims.ExpiresAt.IsZero() || time.Now().After(claims.ExpiresAt.Add(idTokenLeeway)):
+		return nil, fmt.Errorf("%w: token expired", ErrInvalidIDToken)
+	case nonce != nil && claims.Nonce != *nonce:
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrInvalidIDToken)
+	}
+
+	return claims, nil
+}
+
+// idTokenKey returns the public key with the ID, the keys are fetched again once if it's unknown,
+// see RemoteJWKS.Refresh
+func (c *Client) idTokenKey(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	source := c.jwks()
+
+	keys, err := source.JWKS(ctx)
+	if err != nil {
+		return nil, err
+	}
+	key := keys.key(kid)
+	if refresher, ok := source.(interface {
+		Refresh(ctx context.Context) (*JSONWebKeySet, error)
+	}); key == nil && ok {
+		if keys, err = refresher.Refresh(ctx); err != nil {
+			return nil, err
+		}
+		key = keys.key(kid)
+	}
+	if key == nil {
+		return nil, fmt.Errorf("%w: unknown key %q", ErrInvalidIDToken, kid)
+	}
+
+	publicKey, err := key.RSAPublicKey()
+	if err != nil {
+		return nil, fmt.Errorf("%w: key %q: %v", ErrInvalidIDToken, kid, err)
+	}
+	return publicKey, nil
+}
+
+// UserInfo returns the profile claims of the token as returned by GetUserInfo
+func (c *IDTokenClaims) UserInfo() *UserInfo {
+	id := c.UserID
+	if id == "" {
+		id = c.Subject
+	}
+	return &UserInfo{
+		ID:         id,
+		Name:       c.Name,
+		GivenName:  c.GivenName,
+		FamilyName: c.FamilyName,
+		Email:      c.Email,
+		Verified:   c.EmailVerified,
+		Address:    c.Address,
+		PayerID:    c.PayerID,
+	}
+}
+
+func decodeJWTPart(part string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// claims converts the payload, the audience can be a single string or a list
+// and email_verified a boolean or a string
+func (p *idTokenPayload) claims() (*IDTokenClaims, error) {
+	claims := &IDTokenClaims{
+		Issuer:     p.Issuer,
+		Subject:    p.Subject,
+		Nonce:      p.Nonce,
+		UserID:     p.UserID,
+		Name:       p.Name,
+		GivenName:  p.GivenName,
+		FamilyName: p.FamilyName,
+		Email:      p.Email,
+		PayerID:    p.PayerID,
+		Address:    p.Address,
+	}
+
+	var single string
+	if err := json.Unmarshal(p.Audience, &single); err == nil {
+		claims.Audience = []string{single}
+	} else if err := json.Unmarshal(p.Audience, &claims.Audience); err != nil {
+		return nil, fmt.Errorf("invalid audience: %v", err)
+	}
+
+	for _, date := range []struct {
+		value json.Number
+		time  *time.Time
+	}{{p.ExpiresAt, &claims.ExpiresAt}, {p.IssuedAt, &claims.IssuedAt}, {p.AuthTime, &claims.AuthTime}} {
+		if date.value == "" {
+			continue
+		}
+		seconds, err := date.value.Float64()
+		if err != nil {
+			return nil, err
+		}
+		*date.time = time.Unix(int64(seconds), 0)
+	}
+
+	switch verified := p.EmailVerified.(type) {
+	case bool:
+		claims.EmailVerified = verified
+	case string:
+		claims.EmailVerified = verified == "true"
+	}
+
+	return claims, nil
+}
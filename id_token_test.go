@@ -0,0 +1,203 @@
+package paypal
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func signIDToken(t *testing.T, key *rsa.PrivateKey, kid string, claims map[string]interface{}) string {
+	t.Helper()
+
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "kid": kid, "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	digest := sha256.Sum256([]byte(signed))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func publicJWK(key *rsa.PrivateKey, kid string) JSONWebKey {
+	return JSONWebKey{
+		KeyType:   "RSA",
+		KeyID:     kid,
+		Algorithm: "RS256",
+		N:         base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		E:         base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+func TestValidateIDToken(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := NewClient("clientID", "secret", APIBaseSandBox)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	c.SetJWKSSource(StaticJWKS{Keys: []JSONWebKey{publicJWK(key, "key-1")}})
+
+	claims := func(overrides map[string]interface{}) map[string]interface{} {
+		claims := map[string]interface{}{
+			"iss":            IssuerSandBox,
+			"sub":            "https://www.paypal.com/webapps/auth/identity/user/USER",
+			"aud":            "clientID",
+			"exp":            time.Now().Add(time.Hour).Unix(),
+			"iat":            time.Now().Unix(),
+			"nonce":          "nonce-1",
+			"email":          "buyer@example.com",
+			"email_verified": "true",
+			"payer_id":       "PAYER",
+		}
+		for name, value := range overrides {
+			claims[name] = value
+		}
+		return claims
+	}
+
+	idToken := signIDToken(t, key, "key-1", claims(nil))
+	validated, err := c.ValidateIDToken(context.Background(), idToken, "nonce-1")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	userInfo := validated.UserInfo()
+	if userInfo.ID != "https://www.paypal.com/webapps/auth/identity/user/USER" || userInfo.Email != "buyer@example.com" || !userInfo.Verified || userInfo.PayerID != "PAYER" {
+		t.Errorf("unexpected user info %+v", userInfo)
+	}
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rejected := map[string]string{
+		"nonce":     signIDToken(t, key, "key-1", claims(nil)),
+		"audience":  signIDToken(t, key, "key-1", claims(map[string]interface{}{"aud": []string{"otherClientID"}})),
+		"issuer":    signIDToken(t, key, "key-1", claims(map[string]interface{}{"iss": IssuerLive})),
+		"expired":   signIDToken(t, key, "key-1", claims(map[string]interface{}{"exp": time.Now().Add(-time.Hour).Unix()})),
+		"signature": signIDToken(t, otherKey, "key-1", claims(nil)),
+		"key":       signIDToken(t, key, "key-2", claims(nil)),
+		"algorithm": base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`)) + "." + base64.RawURLEncoding.EncodeToString([]byte(`{"aud":"clientID"}`)) + ".",
+	}
+	for reason, idToken := range rejected {
+		nonce := "nonce-1"
+		if reason == "nonce" {
+			nonce = "nonce-2"
+		}
+		if _, err := c.ValidateIDToken(context.Background(), idToken, nonce); !errors.Is(err, ErrInvalidIDToken) {
+			t.Errorf("expected the token with invalid %s to be rejected, got %v", reason, err)
+		}
+	}
+
+	if _, err := c.ValidateIDToken(context.Background(), signIDToken(t, key, "key-1", claims(nil)), ""); err == nil {
+		t.Error("expected an empty nonce to be refused")
+	}
+	if _, err := c.ValidateIDTokenWithoutNonce(context.Background(), signIDToken(t, key, "key-1", claims(nil))); err != nil {
+		t.Errorf("expected the token to be valid without nonce check, got %v", err)
+	}
+}
+
+func TestValidateIDToken_InvalidKeys(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	smallKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := NewClient("clientID", "secret", APIBaseSandBox)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	malformed := publicJWK(key, "malformed")
+	malformed.N = "!!"
+	c.SetJWKSSource(StaticJWKS{Keys: []JSONWebKey{
+		publicJWK(smallKey, "small"),
+		{KeyType: "EC", KeyID: "ec"},
+		malformed,
+	}})
+
+	claims := map[string]interface{}{"iss": IssuerSandBox, "aud": "clientID", "exp": time.Now().Add(time.Hour).Unix()}
+	tokens := map[string]string{
+		"small":     signIDToken(t, smallKey, "small", claims),
+		"ec":        signIDToken(t, key, "ec", claims),
+		"malformed": signIDToken(t, key, "malformed", claims),
+	}
+	for kid, idToken := range tokens {
+		if _, err := c.ValidateIDTokenWithoutNonce(context.Background(), idToken); !errors.Is(err, ErrInvalidIDToken) {
+			t.Errorf("expected the %s key to be refused, got %v", kid, err)
+		}
+	}
+}
+
+func TestRemoteJWKS_RefreshesUnknownKey(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var fetches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		kid := "old-key"
+		if fetches.Add(1) > 1 {
+			kid = "new-key"
+		}
+		_ = json.NewEncoder(w).Encode(JSONWebKeySet{Keys: []JSONWebKey{publicJWK(key, kid)}})
+	}))
+	defer server.Close()
+
+	c, err := NewClient("clientID", "secret", server.URL)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	jwks := NewRemoteJWKS(server.URL, nil)
+	c.SetJWKSSource(jwks)
+
+	claims := map[string]interface{}{"iss": IssuerSandBox, "aud": "clientID", "exp": time.Now().Add(time.Hour).Unix()}
+	for _, kid := range []string{"old-key", "old-key"} {
+		if _, err := c.ValidateIDTokenWithoutNonce(context.Background(), signIDToken(t, key, kid, claims)); err != nil {
+			t.Fatalf("expected no error for %s, got %v", kid, err)
+		}
+	}
+	if fetches.Load() != 1 {
+		t.Errorf("expected the keys to be cached, got %d fetches", fetches.Load())
+	}
+
+	// The keys were just fetched, unknown keys are rejected until MinRefreshInterval elapsed
+	for i := 0; i < 2; i++ {
+		if _, err := c.ValidateIDTokenWithoutNonce(context.Background(), signIDToken(t, key, "new-key", claims)); !errors.Is(err, ErrInvalidIDToken) {
+			t.Fatalf("expected the unknown key to be rejected, got %v", err)
+		}
+	}
+	if fetches.Load() != 1 {
+		t.Errorf("expected no fetch within the minimum refresh interval, got %d fetches", fetches.Load())
+	}
+
+	jwks.mu.Lock()
+	jwks.fetchedAt = jwks.fetchedAt.Add(-DefaultJWKSMinRefreshInterval)
+	jwks.mu.Unlock()
+	if _, err := c.ValidateIDTokenWithoutNonce(context.Background(), signIDToken(t, key, "new-key", claims)); err != nil {
+		t.Fatalf("expected the new key to be fetched, got %v", err)
+	}
+	if fetches.Load() != 2 {
+		t.Errorf("expected the keys to be fetched again for a new key, got %d fetches", fetches.Load())
+	}
+}
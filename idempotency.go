@@ -0,0 +1,75 @@
+package paypal
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type idempotencyKey struct{}
+
+// SetAutoRequestID makes the client attach a PayPal-Request-Id header to every POST and PATCH request
+// which does not have one yet. PayPal processes requests with the same PayPal-Request-Id only once,
+// so they are also retried on transient failures, see SetRetryPolicy.
+// The ID is random, unless a key is set with ContextWithIdempotencyKey.
+func (c *Client) SetAutoRequestID(enabled bool) {
+	c.autoRequestID = enabled
+}
+
+// ContextWithIdempotencyKey returns a context making POST and PATCH calls carry a PayPal-Request-Id
+// derived from key, even if SetAutoRequestID is not enabled. Calling the same endpoint again with the same key,
+// e.g. after a crash, sends the same PayPal-Request-Id so PayPal returns the result of the first call.
+// The ID also depends on the endpoint, so one key can be shared by the calls of a business operation.
+func ContextWithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKey{}, key)
+}
+
+// setRequestID attaches a PayPal-Request-Id to mutating requests, it's kept across retries
+func (c *Client) setRequestID(req *http.Request) error {
+	if req.Method != http.MethodPost && req.Method != http.MethodPatch {
+		return nil
+	}
+	if req.Header.Get("PayPal-Request-Id") != "" || strings.HasPrefix(req.URL.Path, "/v1/oauth2/") {
+		return nil
+	}
+
+	key, _ := req.Context().Value(idempotencyKey{}).(string)
+	switch {
+	case key != "":
+		req.Header.Set("PayPal-Request-Id", deriveRequestID(key, req.Method, req.URL.Path))
+	case c.autoRequestID:
+		id, err := newRequestID()
+		if err != nil {
+			return err
+		}
+		req.Header.Set("PayPal-Request-Id", id)
+	}
+
+	return nil
+}
+
+// deriveRequestID returns a UUID (version 5 layout) identifying the key and the endpoint
+func deriveRequestID(key, method, path string) string {
+	sum := sha256.Sum256([]byte(key + "\x00" + method + "\x00" + path))
+	sum[6] = (sum[6] & 0x0f) | 0x50
+	sum[8] = (sum[8] & 0x3f) | 0x80
+	return formatUUID(sum[:16])
+}
+
+// newRequestID returns a random UUID (version 4)
+func newRequestID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return formatUUID(b), nil
+}
+
+func formatUUID(b []byte) string {
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,69 @@
+package paypal
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+func TestSetAutoRequestID_ReusedAcrossRetries(t *testing.T) {
+	var (
+		mu  sync.Mutex
+		ids []string
+	)
+	c := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		ids = append(ids, r.Header.Get("PayPal-Request-Id"))
+		attempt := len(ids)
+		mu.Unlock()
+
+		if attempt == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"batch_header":{"payout_batch_id":"B-1"}}`))
+	})
+	c.SetAutoRequestID(true)
+
+	if _, err := c.CreatePayout(context.Background(), Payout{}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(ids) != 2 {
+		t.Fatalf("expected the POST request to be retried, got %d attempts", len(ids))
+	}
+	if ids[0] == "" || ids[0] != ids[1] {
+		t.Errorf("expected the same PayPal-Request-Id on every attempt, got %q", ids)
+	}
+}
+
+func TestContextWithIdempotencyKey(t *testing.T) {
+	var ids []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		ids = append(ids, r.Header.Get("PayPal-Request-Id"))
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	ctx := ContextWithIdempotencyKey(context.Background(), "order-42")
+	for range 2 {
+		if _, err := c.CreatePayout(ctx, Payout{}); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+	if _, err := c.CaptureOrder(ctx, "O-1", CaptureOrderRequest{}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := c.GetOrder(ctx, "O-1"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if ids[0] == "" || ids[0] != ids[1] {
+		t.Errorf("expected a stable PayPal-Request-Id for the same key and endpoint, got %q", ids)
+	}
+	if ids[2] == "" || ids[2] == ids[0] {
+		t.Errorf("expected a different PayPal-Request-Id for another endpoint, got %q", ids)
+	}
+	if ids[3] != "" {
+		t.Errorf("expected no PayPal-Request-Id for GET, got %q", ids[3])
+	}
+}
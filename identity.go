@@ -8,7 +8,9 @@ import (
 
 // GetUserInfo - Use this call to retrieve user profile attributes.
 // Endpoint: GET /v1/identity/openidconnect/userinfo/?schema=<Schema>
-func (c *Client) GetUserInfo(ctx context.Context, schema string) (*UserInfo, error) {
+func (c *Client) GetUserInfo(ctx context.Context, schema string, opts ...CallOption) (*UserInfo, error) {
+	ctx = withCallOptions(ctx, opts)
+
 	u := &UserInfo{}
 
 	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s%s%s", c.APIBase, "/v1/identity/openidconnect/userinfo/?schema=", schema), nil)
@@ -7,7 +7,8 @@ import (
 
 // GenerateInvoiceNumber: generates the next invoice number that is available to the merchant.
 // Endpoint: POST /v2/invoicing/generate-next-invoice-number
-func (c *Client) GenerateInvoiceNumber(ctx context.Context) (*InvoiceNumber, error) {
+func (c *Client) GenerateInvoiceNumber(ctx context.Context, opts ...CallOption) (*InvoiceNumber, error) {
+	ctx = withCallOptions(ctx, opts)
 
 	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s", c.APIBase, "/v2/invoicing/generate-next-invoice-number"), nil)
 	nextInvoiceNumber := &InvoiceNumber{}
@@ -24,7 +25,9 @@ func (c *Client) GenerateInvoiceNumber(ctx context.Context) (*InvoiceNumber, err
 
 // GetInvoiceDetails: show invoice details for a particular invoice by ID.
 // Endpoint: GET /v2/invoicing/invoices/{invoice_id}
-func (c *Client) GetInvoiceDetails(ctx context.Context, invoiceID string) (*Invoice, error) {
+func (c *Client) GetInvoiceDetails(ctx context.Context, invoiceID string, opts ...CallOption) (*Invoice, error) {
+	ctx = withCallOptions(ctx, opts)
+
 	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s%s%s", c.APIBase, "/v2/invoicing/invoices/", invoiceID), nil)
 	invoice := &Invoice{}
 	if err != nil {
@@ -0,0 +1,266 @@
+package paypal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Redacted replaces sensitive values in logs
+const Redacted = "[REDACTED]"
+
+// Redact is a set of sensitive data categories removed from logs
+type Redact uint
+
+// Possible values of Redact, they can be combined
+const (
+	// RedactAuthorization removes credentials: Authorization and PayPal-Auth-Assertion headers,
+	// access, refresh and id tokens, client secrets and authorization codes
+	RedactAuthorization Redact = 1 << iota
+	// RedactCardData removes card numbers, security codes and expiry dates
+	RedactCardData
+	// RedactEmails removes email addresses
+	RedactEmails
+	// RedactPhones removes phone numbers
+	RedactPhones
+	// RedactAddresses removes postal addresses
+	RedactAddresses
+
+	// RedactAll removes all known sensitive data
+	RedactAll = RedactAuthorization | RedactCardData | RedactEmails | RedactPhones | RedactAddresses
+)
+
+// LogOptions configures the structured logging enabled with SetLogger
+type LogOptions struct {
+	// LogHeaders adds request headers to the log records
+	LogHeaders bool
+	// LogBodies adds request and response bodies to the log records
+	LogBodies bool
+	// Redact is the set of data removed from headers and bodies
+	Redact Redact
+	// RedactFields are additional JSON fields and form values removed from bodies
+	RedactFields []string
+}
+
+// DefaultLogOptions logs neither headers nor bodies and redacts all sensitive data
+func DefaultLogOptions() LogOptions {
+	return LogOptions{Redact: RedactAll}
+}
+
+type attemptKey struct{}
+
+// withAttempt records the attempt number of a request sent by retry
+func withAttempt(ctx context.Context, attempt int) context.Context {
+	return context.WithValue(ctx, attemptKey{}, attempt)
+}
+
+// attemptFromContext returns the attempt number of a request, 1 if it was never retried
+func attemptFromContext(ctx context.Context) int {
+	if attempt, ok := ctx.Value(attemptKey{}).(int); ok {
+		return attempt
+	}
+	return 1
+}
+
+// SetLogger enables structured logging of every request: method, path, status, PayPal debug ID,
+// duration and attempt. Sensitive data is redacted according to the LogOptions, see SetLogOptions.
+func (c *Client) SetLogger(logger *slog.Logger) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.logger = logger
+	if c.logOptions == nil {
+		options := DefaultLogOptions()
+		c.logOptions = &options
+	}
+}
+
+// SetLogOptions configures the logging enabled with SetLogger
+func (c *Client) SetLogOptions(options LogOptions) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.logOptions = &options
+}
+
+// requestLogger returns the structured logger and its options, nil if logging is disabled
+func (c *Client) requestLogger() (*slog.Logger, LogOptions) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.logger == nil {
+		return nil, LogOptions{}
+	}
+	return c.logger, *c.logOptions
+}
+
+// logAttempt writes a log record for a single attempt to send the request
+func logAttempt(logger *slog.Logger, options LogOptions, req *http.Request, resp *http.Response, err error, duration time.Duration) {
+	ctx := req.Context()
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("path", req.URL.Path),
+		slog.Duration("duration", duration),
+		slog.Int("attempt", attemptFromContext(ctx)),
+	}
+	level := slog.LevelInfo
+
+	var errResp *ErrorResponse
+	if errors.As(err, &errResp) && errResp.Response != nil {
+		resp = errResp.Response
+	}
+	if resp != nil {
+		attrs = append(attrs, slog.Int("status", resp.StatusCode))
+		debugID := resp.Header.Get("Paypal-Debug-Id")
+		if debugID == "" && errResp != nil {
+			debugID = errResp.DebugID
+		}
+		if debugID != "" {
+			attrs = append(attrs, slog.String("debug_id", debugID))
+		}
+	}
+	switch {
+	case errResp != nil:
+		attrs = append(attrs, slog.String("issue", errResp.Issue()))
+		level = slog.LevelWarn
+		if resp != nil && resp.StatusCode >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+	case err != nil:
+		attrs = append(attrs, slog.String("error", err.Error()))
+		level = slog.LevelError
+	}
+
+	if options.LogHeaders {
+		attrs = append(attrs, slog.Any("headers", redactHeaders(req.Header, options)))
+	}
+	if options.LogBodies {
+		if req.GetBody != nil {
+			if body, err := req.GetBody(); err == nil {
+				data, _ := io.ReadAll(body)
+				attrs = append(attrs, slog.String("request_body", redactBody(data, options)))
+			}
+		}
+		if resp != nil && resp.Body != nil {
+			data, _ := io.ReadAll(resp.Body)
+			resp.Body = io.NopCloser(bytes.NewReader(data))
+			attrs = append(attrs, slog.String("response_body", redactBody(data, options)))
+		}
+	}
+
+	logger.LogAttrs(ctx, level, "paypal request", attrs...)
+}
+
+// redactHeaders returns the headers with credentials removed
+func redactHeaders(header http.Header, options LogOptions) map[string]string {
+	headers := make(map[string]string, len(header))
+	for name, values := range header {
+		value := strings.Join(values, ", ")
+		if options.Redact&RedactAuthorization != 0 {
+			switch http.CanonicalHeaderKey(name) {
+			case "Authorization":
+				scheme, _, _ := strings.Cut(value, " ")
+				value = scheme + " " + Redacted
+			case "Paypal-Auth-Assertion":
+				value = Redacted
+			}
+		}
+		headers[name] = value
+	}
+
+	return headers
+}
+
+var emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+
+// redactedFields maps the field names of each Redact category
+var redactedFields = map[Redact][]string{
+	RedactAuthorization: {"access_token", "refresh_token", "id_token", "token", "code", "client_secret", "assertion"},
+	RedactCardData:      {"number", "security_code", "cvv2", "cvv", "expiry", "expire_month", "expire_year"},
+	RedactEmails:        {"email", "email_address", "payer_email", "primary_email"},
+	RedactPhones:        {"phone", "phone_number", "national_number"},
+	RedactAddresses:     {"address", "billing_address", "shipping_address", "addresses", "address_line_1", "address_line_2", "line1", "line2", "postal_code"},
+}
+
+// sensitiveField reports whether the value of the JSON field or form value name must be redacted
+func sensitiveField(name string, options LogOptions) bool {
+	name = strings.ToLower(name)
+	for _, field := range options.RedactFields {
+		if strings.EqualFold(field, name) {
+			return true
+		}
+	}
+	for category, fields := range redactedFields {
+		if options.Redact&category == 0 {
+			continue
+		}
+		for _, field := range fields {
+			if field == name {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// redactBody removes sensitive data from a JSON or form encoded body
+func redactBody(data []byte, options LogOptions) string {
+	if len(data) == 0 {
+		return ""
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err == nil {
+		redacted, err := json.Marshal(redactValue(v, options))
+		if err == nil {
+			return string(redacted)
+		}
+	}
+
+	if values, err := url.ParseQuery(string(data)); err == nil && !bytes.ContainsAny(data, "{[ ") {
+		for name := range values {
+			if sensitiveField(name, options) {
+				values.Set(name, Redacted)
+			}
+		}
+		return values.Encode()
+	}
+
+	if options.Redact&RedactEmails != 0 {
+		return emailPattern.ReplaceAllString(string(data), Redacted)
+	}
+	return string(data)
+}
+
+// redactValue walks a decoded JSON value and replaces sensitive fields
+func redactValue(v interface{}, options LogOptions) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for name, field := range value {
+			if sensitiveField(name, options) {
+				value[name] = Redacted
+			} else {
+				value[name] = redactValue(field, options)
+			}
+		}
+	case []interface{}:
+		for i := range value {
+			value[i] = redactValue(value[i], options)
+		}
+	case string:
+		if options.Redact&RedactEmails != 0 {
+			return emailPattern.ReplaceAllString(value, Redacted)
+		}
+	}
+
+	return v
+}
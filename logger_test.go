@@ -0,0 +1,54 @@
+package paypal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestSetLogger_RedactsSensitiveData(t *testing.T) {
+	c := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Paypal-Debug-Id", "debug-1")
+		_, _ = w.Write([]byte(`{"id":"O-1","payer":{"email_address":"buyer@example.com","address":{"country_code":"US"}}}`))
+	})
+
+	var buf bytes.Buffer
+	c.SetLogger(slog.New(slog.NewJSONHandler(&buf, nil)))
+	c.SetLogOptions(LogOptions{LogHeaders: true, LogBodies: true, Redact: RedactAll})
+
+	source := &PaymentSource{Card: &PaymentSourceCard{Number: "4111111111111111", SecurityCode: "123", Name: "John"}}
+	if _, err := c.CreateOrder(context.Background(), OrderIntentCapture, nil, source, nil); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	log := buf.String()
+	for _, secret := range []string{"4111111111111111", `"123"`, "buyer@example.com", "Bearer dummy", "country_code"} {
+		if strings.Contains(log, secret) {
+			t.Errorf("log contains %s: %s", secret, log)
+		}
+	}
+
+	var record map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("failed to decode log record: %v", err)
+	}
+	for field, expected := range map[string]interface{}{"method": "POST", "path": "/v2/checkout/orders", "status": 200.0, "debug_id": "debug-1", "attempt": 1.0} {
+		if record[field] != expected {
+			t.Errorf("got %s=%v, wanted %v", field, record[field], expected)
+		}
+	}
+	if !strings.Contains(record["request_body"].(string), `"name":"John"`) {
+		t.Errorf("expected non-sensitive fields to be kept: %v", record["request_body"])
+	}
+}
+
+func TestRedactBody_Form(t *testing.T) {
+	body := redactBody([]byte("grant_type=authorization_code&code=secret-code"), DefaultLogOptions())
+	if body != "code=%5BREDACTED%5D&grant_type=authorization_code" {
+		t.Errorf("unexpected redacted body %s", body)
+	}
+}
@@ -0,0 +1,98 @@
+package paypal
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// GetMerchantIntegration shows the onboarding status of a seller onboarded with CreatePartnerReferral.
+// Call it when receiving the EventMerchantOnboardingCompleted and EventMerchantPartnerConsentRevoked webhooks.
+// Endpoint: GET /v1/customer/partners/ID/merchant-integrations/ID
+func (c *Client) GetMerchantIntegration(ctx context.Context, partnerID, merchantID string, opts ...CallOption) (*MerchantIntegration, error) {
+	ctx = withCallOptions(ctx, opts)
+
+	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s/v1/customer/partners/%s/merchant-integrations/%s", c.APIBase, partnerID, merchantID), nil)
+	response := &MerchantIntegration{}
+
+	if err != nil {
+		return response, err
+	}
+
+	if err = c.SendWithAuth(req, response); err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// FindMerchantIntegration returns the merchant ID of the seller onboarded with the tracking ID of a partner referral
+// Endpoint: GET /v1/customer/partners/ID/merchant-integrations?tracking_id=ID
+func (c *Client) FindMerchantIntegration(ctx context.Context, partnerID, trackingID string, opts ...CallOption) (*MerchantIntegrationLookup, error) {
+	ctx = withCallOptions(ctx, opts)
+
+	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s/v1/customer/partners/%s/merchant-integrations?tracking_id=%s", c.APIBase, partnerID, url.QueryEscape(trackingID)), nil)
+	response := &MerchantIntegrationLookup{}
+
+	if err != nil {
+		return response, err
+	}
+
+	if err = c.SendWithAuth(req, response); err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// ReadyToTransact reports whether the partner with the client ID partnerClientID, usually Client.ClientID,
+// can process payments for the seller: the seller can receive payments, confirmed their primary email
+// and granted permissions to this partner.
+// It turns false once the seller revokes the consent (EventMerchantPartnerConsentRevoked).
+func (m *MerchantIntegration) ReadyToTransact(partnerClientID string) bool {
+	return len(m.NotReadyReasons(partnerClientID)) == 0
+}
+
+// NotReadyReasons lists what prevents the seller from transacting with the partner, empty if ReadyToTransact
+func (m *MerchantIntegration) NotReadyReasons(partnerClientID string) []string {
+	var reasons []string
+	if !m.PaymentsReceivable {
+		reasons = append(reasons, "payments are not receivable, the seller account is restricted")
+	}
+	if !m.PrimaryEmailConfirmed {
+		reasons = append(reasons, "the seller did not confirm the primary email")
+	}
+	if !m.permissionsGranted(partnerClientID) {
+		reasons = append(reasons, "the seller did not grant permissions to the partner")
+	}
+	for _, product := range m.Products {
+		if product.VettingStatus == VettingStatusDenied {
+			reasons = append(reasons, "product "+product.Name+" was denied")
+		}
+	}
+
+	return reasons
+}
+
+// Capability returns the capability with the given name, nil if the seller does not have it
+func (m *MerchantIntegration) Capability(name string) *MerchantCapability {
+	for i := range m.Capabilities {
+		if m.Capabilities[i].Name == name {
+			return &m.Capabilities[i]
+		}
+	}
+	return nil
+}
+
+// permissionsGranted reports whether the seller granted at least one scope to the partner,
+// scopes granted to other partners do not count
+func (m *MerchantIntegration) permissionsGranted(partnerClientID string) bool {
+	for _, integration := range m.OAuthIntegrations {
+		for _, thirdParty := range integration.OAuthThirdParty {
+			if thirdParty.PartnerClientID == partnerClientID && len(thirdParty.Scopes) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
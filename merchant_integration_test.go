@@ -0,0 +1,59 @@
+package paypal
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestGetMerchantIntegration(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/customer/partners/PARTNER/merchant-integrations/MERCHANT":
+			_, _ = w.Write([]byte(`{
+				"merchant_id": "MERCHANT",
+				"tracking_id": "seller-1",
+				"payments_receivable": true,
+				"primary_email_confirmed": true,
+				"products": [{"name": "EXPRESS_CHECKOUT", "vetting_status": "SUBSCRIBED"}],
+				"capabilities": [{"name": "CUSTOM_CARD_PROCESSING", "status": "ACTIVE"}],
+				"oauth_integrations": [{
+					"integration_type": "OAUTH_THIRD_PARTY",
+					"oauth_third_party": [{"partner_client_id": "clientID", "scopes": ["https://uri.paypal.com/services/payments/realtimepayment"]}]
+				}]
+			}`))
+		case "/v1/customer/partners/PARTNER/merchant-integrations":
+			if r.URL.Query().Get("tracking_id") != "seller-1" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write([]byte(`{"merchant_id": "MERCHANT", "tracking_id": "seller-1"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	lookup, err := c.FindMerchantIntegration(context.Background(), "PARTNER", "seller-1")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	status, err := c.GetMerchantIntegration(context.Background(), "PARTNER", lookup.MerchantID)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !status.ReadyToTransact(c.ClientID) {
+		t.Errorf("expected the seller to be ready, got %v", status.NotReadyReasons(c.ClientID))
+	}
+	if status.ReadyToTransact("otherPartner") {
+		t.Errorf("expected permissions granted to another partner not to count")
+	}
+	if capability := status.Capability("CUSTOM_CARD_PROCESSING"); capability == nil || capability.Status != CapabilityStatusActive {
+		t.Errorf("expected an active capability, got %+v", capability)
+	}
+
+	// The consent is revoked
+	status.OAuthIntegrations = nil
+	if status.ReadyToTransact(c.ClientID) || len(status.NotReadyReasons(c.ClientID)) != 1 {
+		t.Errorf("expected the seller not to be ready, got %v", status.NotReadyReasons(c.ClientID))
+	}
+}
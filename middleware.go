@@ -0,0 +1,67 @@
+package paypal
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+type (
+	// RoundTripFunc sends a single request to PayPal.
+	// Responses with a non-2xx status code are returned as *ErrorResponse,
+	// successful responses still have their body to be decoded by Send.
+	RoundTripFunc func(req *http.Request) (*http.Response, error)
+
+	// Middleware intercepts the requests sent by the client, e.g. to add headers,
+	// record metrics or audit calls. It must call next to send the request.
+	Middleware func(next RoundTripFunc) RoundTripFunc
+)
+
+// Use registers middlewares around all requests sent by the client, including token requests.
+// The first registered middleware is the outermost one.
+func (c *Client) Use(middlewares ...Middleware) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.middlewares = append(c.middlewares, middlewares...)
+}
+
+// roundTrip sends the request through the middleware chain
+func (c *Client) roundTrip(req *http.Request) (*http.Response, error) {
+	c.mu.Lock()
+	middlewares := c.middlewares
+	c.mu.Unlock()
+
+	next := RoundTripFunc(c.sendWithRetry)
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		next = middlewares[i](next)
+	}
+
+	return next(req)
+}
+
+// LoggingMiddleware writes a line with the method, URL, status code and duration of every request to w.
+// Unlike SetLog it never writes headers or bodies.
+func LoggingMiddleware(w io.Writer) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next(req)
+			duration := time.Since(start)
+
+			var errResp *ErrorResponse
+			switch {
+			case errors.As(err, &errResp) && errResp.Response != nil:
+				_, _ = fmt.Fprintf(w, "%s %s %d %s debug_id=%s issue=%s\n", req.Method, req.URL.Path, errResp.Response.StatusCode, duration, errResp.DebugID, errResp.Issue())
+			case err != nil:
+				_, _ = fmt.Fprintf(w, "%s %s error %s: %v\n", req.Method, req.URL.Path, duration, err)
+			default:
+				_, _ = fmt.Fprintf(w, "%s %s %d %s\n", req.Method, req.URL.Path, resp.StatusCode, duration)
+			}
+
+			return resp, err
+		}
+	}
+}
@@ -27,13 +27,6 @@ func (d dummyPutResponse) VisitTrackersPutResponse(w http.ResponseWriter) error
 	return nil
 }
 
-type dummyBatchPostResponse struct{}
-
-func (d dummyBatchPostResponse) VisitTrackersBatchPostResponse(w http.ResponseWriter) error {
-	w.WriteHeader(200)
-	return nil
-}
-
 var dummyPayout = srv.Payout{
 	BatchHeader: &srv.PayoutHeader{
 		BatchStatus:   "SUCCESS",
@@ -110,7 +103,18 @@ func (m *MockServer) TrackersPost(ctx context.Context, request srvShipping.Track
 }
 
 func (m *MockServer) TrackersBatchPost(ctx context.Context, request srvShipping.TrackersBatchPostRequestObject) (srvShipping.TrackersBatchPostResponseObject, error) {
-	return dummyBatchPostResponse{}, nil
+	identifiers := srvShipping.TrackerIdentifierList{}
+	if request.Body != nil && request.Body.Trackers != nil {
+		for _, tracker := range *request.Body.Trackers {
+			identifiers = append(identifiers, srvShipping.TrackerIdentifier{
+				TransactionId:  tracker.TransactionId,
+				TrackingNumber: tracker.TrackingNumber,
+			})
+		}
+	}
+	return srvShipping.TrackersBatchPost200JSONResponse(srvShipping.BatchTrackerCollection{
+		TrackerIdentifiers: &identifiers,
+	}), nil
 }
 
 func (m *MockServer) TrackersGet(ctx context.Context, request srvShipping.TrackersGetRequestObject) (srvShipping.TrackersGetResponseObject, error) {
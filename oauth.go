@@ -0,0 +1,147 @@
+package paypal
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	// ConnectURLSandBox is the Log in with PayPal authorization page of the sandbox
+	ConnectURLSandBox = "https://www.sandbox.paypal.com/connect"
+
+	// ConnectURLLive is the Log in with PayPal authorization page of the live environment
+	ConnectURLLive = "https://www.paypal.com/connect"
+)
+
+// Scopes of Log in with PayPal
+//
+// https://developer.paypal.com/docs/log-in-with-paypal/integrate/reference/#scope-attributes
+const (
+	ScopeOpenID            string = "openid"
+	ScopeProfile           string = "profile"
+	ScopeEmail             string = "email"
+	ScopeAddress           string = "address"
+	ScopePayPalAttributes  string = "https://uri.paypal.com/services/paypalattributes"
+	ScopeAccountVerifiable string = "https://uri.paypal.com/services/paypalattributes/business"
+)
+
+// Possible values of the token type hint of RevokeToken
+const (
+	TokenTypeHintAccessToken  string = "ACCESS_TOKEN"
+	TokenTypeHintRefreshToken string = "REFRESH_TOKEN"
+)
+
+// AuthorizeURLParams are the parameters of the Log in with PayPal authorization page
+type AuthorizeURLParams struct {
+	// RedirectURI must match the return URL configured for the app
+	RedirectURI string
+	// Scopes requested from the user, openid is always requested
+	Scopes []string
+	// State is returned unchanged to the RedirectURI, use it to prevent CSRF
+	State string
+	// Nonce is copied to the ID token, see ValidateIDToken
+	Nonce string
+	// ConnectURL overrides the authorization page, by default it's chosen from the APIBase of the client
+	ConnectURL string
+}
+
+// AuthorizeURL returns the URL of the Log in with PayPal page the user must be redirected to.
+// PayPal redirects the user back to RedirectURI with the code to exchange with ExchangeAuthorizationCode.
+func (c *Client) AuthorizeURL(params AuthorizeURLParams) string {
+	connectURL := params.ConnectURL
+	if connectURL == "" {
+		connectURL = ConnectURLSandBox
+		if c.APIBase == APIBaseLive {
+			connectURL = ConnectURLLive
+		}
+	}
+
+	scopes := []string{ScopeOpenID}
+	for _, scope := range params.Scopes {
+		if scope != ScopeOpenID {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	query := url.Values{}
+	query.Set("flowEntry", "static")
+	query.Set("client_id", c.ClientID)
+	query.Set("response_type", "code")
+	query.Set("scope", strings.Join(scopes, " "))
+	query.Set("redirect_uri", params.RedirectURI)
+	if params.State != "" {
+		query.Set("state", params.State)
+	}
+	if params.Nonce != "" {
+		query.Set("nonce", params.Nonce)
+	}
+
+	return connectURL + "?" + query.Encode()
+}
+
+// ExchangeAuthorizationCode returns the tokens of the user who authorized the app on the AuthorizeURL page.
+// The user tokens are not used by the client, pass the access token to calls made for the user with WithAccessToken.
+// Endpoint: POST /v1/oauth2/token
+func (c *Client) ExchangeAuthorizationCode(ctx context.Context, code string, opts ...CallOption) (*TokenResponse, error) {
+	ctx = withCallOptions(ctx, opts)
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+
+	return c.requestUserToken(ctx, form)
+}
+
+// RefreshUserToken returns a new access token of the user from the refresh token obtained with ExchangeAuthorizationCode
+// Endpoint: POST /v1/oauth2/token
+func (c *Client) RefreshUserToken(ctx context.Context, refreshToken string, opts ...CallOption) (*TokenResponse, error) {
+	ctx = withCallOptions(ctx, opts)
+
+	form := url.Values{}
+	form.Set("grant_type", "refresh_token")
+	form.Set("refresh_token", refreshToken)
+
+	return c.requestUserToken(ctx, form)
+}
+
+// RevokeToken revokes an access or refresh token of a user, e.g. when the user unlinks the account.
+// tokenTypeHint is TokenTypeHintAccessToken or TokenTypeHintRefreshToken.
+// Endpoint: POST /v1/oauth2/revoke
+func (c *Client) RevokeToken(ctx context.Context, token string, tokenTypeHint string, opts ...CallOption) error {
+	ctx = withCallOptions(ctx, opts)
+
+	form := url.Values{}
+	form.Set("token", token)
+	if tokenTypeHint != "" {
+		form.Set("token_type_hint", tokenTypeHint)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s%s", c.APIBase, "/v1/oauth2/revoke"), bytes.NewBufferString(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-type", "application/x-www-form-urlencoded")
+
+	return c.SendWithBasicAuth(req, nil)
+}
+
+// requestUserToken sends a token request for a user, unlike GetAccessToken the token is not applied to the client
+func (c *Client) requestUserToken(ctx context.Context, form url.Values) (*TokenResponse, error) {
+	response := &TokenResponse{}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s%s", c.APIBase, "/v1/oauth2/token"), bytes.NewBufferString(form.Encode()))
+	if err != nil {
+		return response, err
+	}
+	req.Header.Set("Content-type", "application/x-www-form-urlencoded")
+
+	if err = c.SendWithBasicAuth(req, response); err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
@@ -0,0 +1,139 @@
+package paypal
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestAuthorizeURL(t *testing.T) {
+	c, err := NewClient("clientID", "secret", APIBaseLive)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	authorizeURL := c.AuthorizeURL(AuthorizeURLParams{
+		RedirectURI: "https://example.com/callback",
+		Scopes:      []string{ScopeEmail, ScopeOpenID},
+		State:       "state-1",
+		Nonce:       "nonce-1",
+	})
+
+	u, err := url.Parse(authorizeURL)
+	if err != nil {
+		t.Fatalf("invalid url %q: %v", authorizeURL, err)
+	}
+	if u.Scheme+"://"+u.Host+u.Path != ConnectURLLive {
+		t.Errorf("expected the live connect url, got %q", authorizeURL)
+	}
+	query := u.Query()
+	expected := map[string]string{
+		"client_id":     "clientID",
+		"response_type": "code",
+		"scope":         "openid email",
+		"redirect_uri":  "https://example.com/callback",
+		"state":         "state-1",
+		"nonce":         "nonce-1",
+	}
+	for name, value := range expected {
+		if query.Get(name) != value {
+			t.Errorf("expected %s %q, got %q", name, value, query.Get(name))
+		}
+	}
+}
+
+func TestUserTokens(t *testing.T) {
+	var forms []url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/oauth2/token", "/v1/oauth2/revoke":
+			if user, _, _ := r.BasicAuth(); user != "clientID" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_ = r.ParseForm()
+			forms = append(forms, r.PostForm)
+			_, _ = w.Write([]byte(`{"access_token":"user-token","refresh_token":"refresh-token","id_token":"id-token","expires_in":28800}`))
+		case "/v1/identity/openidconnect/userinfo/":
+			if r.Header.Get("Authorization") != "Bearer user-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(`{"user_id":"https://www.paypal.com/webapps/auth/identity/user/USER","email":"buyer@example.com"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c, err := NewClient("clientID", "secret", server.URL)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	token, err := c.ExchangeAuthorizationCode(context.Background(), "code-1")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if token.Token != "user-token" || token.IDToken != "id-token" {
+		t.Errorf("unexpected token %+v", token)
+	}
+	if c.Token != nil {
+		t.Errorf("expected the user token not to be used by the client")
+	}
+
+	userInfo, err := c.GetUserInfo(context.Background(), "openid", WithAccessToken(token.Token))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if userInfo.Email != "buyer@example.com" {
+		t.Errorf("unexpected user info %+v", userInfo)
+	}
+
+	if _, err := c.RefreshUserToken(context.Background(), token.RefreshToken); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := c.RevokeToken(context.Background(), token.RefreshToken, TokenTypeHintRefreshToken); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(forms) != 3 {
+		t.Fatalf("expected 3 token requests, got %d", len(forms))
+	}
+	if forms[0].Get("grant_type") != "authorization_code" || forms[0].Get("code") != "code-1" {
+		t.Errorf("unexpected code exchange %v", forms[0])
+	}
+	if forms[1].Get("grant_type") != "refresh_token" || forms[1].Get("refresh_token") != "refresh-token" {
+		t.Errorf("unexpected refresh %v", forms[1])
+	}
+	if forms[2].Get("token") != "refresh-token" || forms[2].Get("token_type_hint") != TokenTypeHintRefreshToken {
+		t.Errorf("unexpected revocation %v", forms[2])
+	}
+}
+
+func TestUserTokens_OnlyIdempotentGrantsAreRetried(t *testing.T) {
+	attempts := map[string]int{}
+	c := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		grantType := r.PostForm.Get("grant_type")
+		attempts[grantType]++
+		if attempts[grantType] == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"access_token":"user-token"}`))
+	})
+
+	// Authorization codes are single-use, a retry after a lost response would fail with invalid_grant
+	if _, err := c.ExchangeAuthorizationCode(context.Background(), "code-1"); err == nil {
+		t.Errorf("expected the code exchange not to be retried")
+	}
+	if _, err := c.RefreshUserToken(context.Background(), "refresh-token"); err != nil {
+		t.Errorf("expected the refresh to be retried, got %v", err)
+	}
+	if attempts["authorization_code"] != 1 || attempts["refresh_token"] != 2 {
+		t.Errorf("unexpected attempts %v", attempts)
+	}
+}
@@ -0,0 +1,105 @@
+package paypal
+
+import (
+	"net/http"
+	"strings"
+)
+
+// operationResource maps an API path prefix to the name of the resource in operation names
+type operationResource struct {
+	prefix string
+	name   string
+	// action is set for endpoints which are a single operation rather than a collection
+	action bool
+}
+
+// operationResources is sorted so that longer prefixes come first
+var operationResources = []operationResource{
+	{prefix: "/v1/oauth2/token", name: "oauth2.token", action: true},
+	{prefix: "/v1/oauth2/revoke", name: "oauth2.revoke", action: true},
+	{prefix: "/v1/identity/openidconnect/userinfo", name: "identity.userinfo", action: true},
+	{prefix: "/v2/checkout/orders", name: "orders"},
+	{prefix: "/v2/payments/authorizations", name: "authorizations"},
+	{prefix: "/v2/payments/captures", name: "captures"},
+	{prefix: "/v2/payments/refunds", name: "refunds"},
+	{prefix: "/v1/payments/payouts-item", name: "payouts.items"},
+	{prefix: "/v1/payments/payouts", name: "payouts"},
+	{prefix: "/v1/billing/subscriptions", name: "subscriptions"},
+	{prefix: "/v1/billing/plans", name: "plans"},
+	{prefix: "/v1/catalogs/products", name: "products"},
+	{prefix: "/v1/customer/disputes", name: "disputes"},
+	{prefix: "/v1/customer/partners", name: "partners"},
+	{prefix: "/v2/customer/partner-referrals", name: "partner_referrals"},
+	{prefix: "/v1/reporting/transactions", name: "transactions"},
+	{prefix: "/v1/notifications/verify-webhook-signature", name: "webhooks.verify_signature", action: true},
+	{prefix: "/v1/notifications/webhooks-event-types", name: "webhooks.event_types"},
+	{prefix: "/v1/notifications/webhooks", name: "webhooks"},
+	{prefix: "/v2/invoicing/generate-next-invoice-number", name: "invoices.generate_next_number", action: true},
+	{prefix: "/v2/invoicing/invoices", name: "invoices"},
+	{prefix: "/v1/vault/credit-cards", name: "credit_cards"},
+	{prefix: "/v1/payment-experience/web-profiles", name: "web_profiles"},
+	{prefix: "/v1/risk/transaction-contexts", name: "risk.transaction_contexts", action: true},
+	{prefix: "/v1/shipping/trackers-batch", name: "trackers.batch", action: true},
+	{prefix: "/v1/shipping/trackers", name: "trackers"},
+}
+
+// OperationName returns the logical name of the API operation called by the request,
+// e.g. paypal.orders.capture for POST /v2/checkout/orders/ID/capture.
+// Requests to unknown endpoints are named paypal.request.
+func OperationName(req *http.Request) string {
+	return operationName(req.Method, req.URL.Path)
+}
+
+func operationName(method, path string) string {
+	path = strings.TrimSuffix(path, "/")
+
+	for _, resource := range operationResources {
+		if path != resource.prefix && !strings.HasPrefix(path, resource.prefix+"/") {
+			continue
+		}
+		if resource.action {
+			return "paypal." + resource.name
+		}
+
+		rest := strings.Split(strings.TrimPrefix(path[len(resource.prefix):], "/"), "/")
+		if rest[0] == "" {
+			rest = nil
+		}
+
+		// rest alternates between IDs and sub-resources or actions: ID/action/ID/...
+		name := "paypal." + resource.name
+		for i := 1; i < len(rest); i += 2 {
+			name += "." + strings.ReplaceAll(rest[i], "-", "_")
+		}
+		switch {
+		case len(rest) == 0:
+			return name + "." + operationVerb(method, true)
+		case len(rest)%2 == 1:
+			return name + "." + operationVerb(method, false)
+		case method == http.MethodGet:
+			// GET of a sub-collection, e.g. /v1/billing/subscriptions/ID/transactions
+			return name + ".list"
+		}
+		return name
+	}
+
+	return "paypal.request"
+}
+
+// operationVerb names a CRUD operation on a collection or a single resource
+func operationVerb(method string, collection bool) string {
+	switch method {
+	case http.MethodGet:
+		if collection {
+			return "list"
+		}
+		return "get"
+	case http.MethodPost:
+		return "create"
+	case http.MethodPut, http.MethodPatch:
+		return "update"
+	case http.MethodDelete:
+		return "delete"
+	}
+	return strings.ToLower(method)
+}
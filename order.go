@@ -8,7 +8,9 @@ import (
 
 // GetOrder retrieves order by ID
 // Endpoint: GET /v2/checkout/orders/ID
-func (c *Client) GetOrder(ctx context.Context, orderID string) (*Order, error) {
+func (c *Client) GetOrder(ctx context.Context, orderID string, opts ...CallOption) (*Order, error) {
+	ctx = withCallOptions(ctx, opts)
+
 	order := &Order{}
 
 	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s%s%s", c.APIBase, "/v2/checkout/orders/", orderID), nil)
@@ -25,8 +27,8 @@ func (c *Client) GetOrder(ctx context.Context, orderID string) (*Order, error) {
 
 // CreateOrder Create an order
 // Endpoint: POST /v2/checkout/orders
-func (c *Client) CreateOrder(ctx context.Context, intent string, purchaseUnits []PurchaseUnitRequest, paymentSource *PaymentSource, appContext *ApplicationContext) (*Order, error) {
-	return c.CreateOrderWithPaypalRequestID(ctx, intent, purchaseUnits, paymentSource, appContext, "")
+func (c *Client) CreateOrder(ctx context.Context, intent string, purchaseUnits []PurchaseUnitRequest, paymentSource *PaymentSource, appContext *ApplicationContext, opts ...CallOption) (*Order, error) {
+	return c.CreateOrderWithPaypalRequestID(ctx, intent, purchaseUnits, paymentSource, appContext, "", opts...)
 }
 
 // CreateOrderWithPaypalRequestID - Use this call to create an order with idempotency
@@ -37,7 +39,10 @@ func (c *Client) CreateOrderWithPaypalRequestID(ctx context.Context,
 	paymentSource *PaymentSource,
 	appContext *ApplicationContext,
 	requestID string,
+	opts ...CallOption,
 ) (*Order, error) {
+	ctx = withCallOptions(ctx, opts)
+
 	type createOrderRequest struct {
 		Intent             string                `json:"intent"`
 		PaymentSource      *PaymentSource        `json:"payment_source,omitempty"`
@@ -63,23 +68,18 @@ func (c *Client) CreateOrderWithPaypalRequestID(ctx context.Context,
 	return order, nil
 }
 
-// UpdateOrder updates the order by ID
+// UpdateOrder updates the order by ID, see UpdateOrderPatches to send several operations at once
 // Endpoint: PATCH /v2/checkout/orders/ID
-func (c *Client) UpdateOrder(ctx context.Context, orderID string, op string, path string, value map[string]string) error {
+func (c *Client) UpdateOrder(ctx context.Context, orderID string, op string, path string, value map[string]string, opts ...CallOption) error {
+	return c.UpdateOrderPatches(ctx, orderID, []Patch{{Operation: op, Path: path, Value: value}}, opts...)
+}
 
-	type patchRequest struct {
-		Op    string            `json:"op"`
-		Path  string            `json:"path"`
-		Value map[string]string `json:"value"`
-	}
+// UpdateOrderPatches updates the order by ID with JSON patches, see PatchPurchaseUnit to build them
+// Endpoint: PATCH /v2/checkout/orders/ID
+func (c *Client) UpdateOrderPatches(ctx context.Context, orderID string, patches []Patch, opts ...CallOption) error {
+	ctx = withCallOptions(ctx, opts)
 
-	req, err := c.NewRequest(ctx, "PATCH", fmt.Sprintf("%s%s%s", c.APIBase, "/v2/checkout/orders/", orderID), []patchRequest{
-		{
-			Op:    op,
-			Path:  path,
-			Value: value,
-		},
-	})
+	req, err := c.NewRequest(ctx, "PATCH", fmt.Sprintf("%s%s%s", c.APIBase, "/v2/checkout/orders/", orderID), patches)
 	if err != nil {
 		return err
 	}
@@ -92,7 +92,9 @@ func (c *Client) UpdateOrder(ctx context.Context, orderID string, op string, pat
 
 // AuthorizeOrder - https://developer.paypal.com/docs/api/orders/v2/#orders_authorize
 // Endpoint: POST /v2/checkout/orders/ID/authorize
-func (c *Client) AuthorizeOrder(ctx context.Context, orderID string, authorizeOrderRequest AuthorizeOrderRequest) (*AuthorizeOrderResponse, error) {
+func (c *Client) AuthorizeOrder(ctx context.Context, orderID string, authorizeOrderRequest AuthorizeOrderRequest, opts ...CallOption) (*AuthorizeOrderResponse, error) {
+	ctx = withCallOptions(ctx, opts)
+
 	auth := &AuthorizeOrderResponse{}
 
 	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s", c.APIBase, "/v2/checkout/orders/"+orderID+"/authorize"), authorizeOrderRequest)
@@ -109,8 +111,8 @@ func (c *Client) AuthorizeOrder(ctx context.Context, orderID string, authorizeOr
 
 // CaptureOrder - https://developer.paypal.com/docs/api/orders/v2/#orders_capture
 // Endpoint: POST /v2/checkout/orders/ID/capture
-func (c *Client) CaptureOrder(ctx context.Context, orderID string, captureOrderRequest CaptureOrderRequest) (*CaptureOrderResponse, error) {
-	return c.CaptureOrderWithPaypalRequestId(ctx, orderID, captureOrderRequest, "", nil)
+func (c *Client) CaptureOrder(ctx context.Context, orderID string, captureOrderRequest CaptureOrderRequest, opts ...CallOption) (*CaptureOrderResponse, error) {
+	return c.CaptureOrderWithPaypalRequestId(ctx, orderID, captureOrderRequest, "", nil, opts...)
 }
 
 // CaptureOrderWithPaypalRequestId with idempotency - https://developer.paypal.com/docs/api/orders/v2/#orders_capture
@@ -121,15 +123,19 @@ func (c *Client) CaptureOrderWithPaypalRequestId(ctx context.Context,
 	captureOrderRequest CaptureOrderRequest,
 	requestID string,
 	mockResponse *CaptureOrderMockResponse,
+	opts ...CallOption,
 ) (*CaptureOrderResponse, error) {
+	ctx = withCallOptions(ctx, opts)
+
 	capture := &CaptureOrderResponse{}
 
-	c.SetReturnRepresentation()
 	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s", c.APIBase, "/v2/checkout/orders/"+orderID+"/capture"), captureOrderRequest)
 	if err != nil {
 		return capture, err
 	}
 
+	req.Header.Set("Prefer", "return=representation")
+
 	if requestID != "" {
 		req.Header.Set("PayPal-Request-Id", requestID)
 	}
@@ -155,10 +161,64 @@ func (c *Client) CaptureOrderWithPaypalRequestId(ctx context.Context,
 	return capture, nil
 }
 
+// ConfirmOrderPaymentSource confirms the payment source of the order, e.g. a card or a vaulted token,
+// before it is authorized or captured - https://developer.paypal.com/docs/api/orders/v2/#orders_confirm
+// Endpoint: POST /v2/checkout/orders/ID/confirm-payment-source
+func (c *Client) ConfirmOrderPaymentSource(ctx context.Context, orderID string, confirmRequest ConfirmPaymentSourceRequest, opts ...CallOption) (*Order, error) {
+	ctx = withCallOptions(ctx, opts)
+
+	order := &Order{}
+
+	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s", c.APIBase, "/v2/checkout/orders/"+orderID+"/confirm-payment-source"), confirmRequest)
+	if err != nil {
+		return order, err
+	}
+
+	if err = c.SendWithAuth(req, order); err != nil {
+		return order, err
+	}
+
+	return order, nil
+}
+
+// AddOrderTracker adds tracking information of a captured order, the shipment releases seller protection holds
+// https://developer.paypal.com/docs/api/orders/v2/#orders_track_create
+// Endpoint: POST /v2/checkout/orders/ID/track
+func (c *Client) AddOrderTracker(ctx context.Context, orderID string, trackerRequest OrderTrackerRequest, opts ...CallOption) (*Order, error) {
+	ctx = withCallOptions(ctx, opts)
+
+	order := &Order{}
+
+	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s", c.APIBase, "/v2/checkout/orders/"+orderID+"/track"), trackerRequest)
+	if err != nil {
+		return order, err
+	}
+
+	if err = c.SendWithAuth(req, order); err != nil {
+		return order, err
+	}
+
+	return order, nil
+}
+
+// UpdateOrderTracker updates or cancels a tracker of the order, e.g. replacing /status with OrderTrackerStatusCancelled
+// https://developer.paypal.com/docs/api/orders/v2/#orders_trackers_patch
+// Endpoint: PATCH /v2/checkout/orders/ID/trackers/TRACKER_ID
+func (c *Client) UpdateOrderTracker(ctx context.Context, orderID string, trackerID string, patches []Patch, opts ...CallOption) error {
+	ctx = withCallOptions(ctx, opts)
+
+	req, err := c.NewRequest(ctx, "PATCH", fmt.Sprintf("%s%s", c.APIBase, "/v2/checkout/orders/"+orderID+"/trackers/"+trackerID), patches)
+	if err != nil {
+		return err
+	}
+
+	return c.SendWithAuth(req, nil)
+}
+
 // RefundCapture - https://developer.paypal.com/docs/api/payments/v2/#captures_refund
 // Endpoint: POST /v2/payments/captures/ID/refund
-func (c *Client) RefundCapture(ctx context.Context, captureID string, refundCaptureRequest RefundCaptureRequest) (*RefundResponse, error) {
-	return c.RefundCaptureWithPaypalRequestId(ctx, captureID, refundCaptureRequest, "")
+func (c *Client) RefundCapture(ctx context.Context, captureID string, refundCaptureRequest RefundCaptureRequest, opts ...CallOption) (*RefundResponse, error) {
+	return c.RefundCaptureWithPaypalRequestId(ctx, captureID, refundCaptureRequest, "", opts...)
 }
 
 // RefundCaptureWithPaypalRequestId with idempotency - https://developer.paypal.com/docs/api/payments/v2/#captures_refund
@@ -167,7 +227,10 @@ func (c *Client) RefundCaptureWithPaypalRequestId(ctx context.Context,
 	captureID string,
 	refundCaptureRequest RefundCaptureRequest,
 	requestID string,
+	opts ...CallOption,
 ) (*RefundResponse, error) {
+	ctx = withCallOptions(ctx, opts)
+
 	refund := &RefundResponse{}
 
 	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s", c.APIBase, "/v2/payments/captures/"+captureID+"/refund"), refundCaptureRequest)
@@ -187,7 +250,9 @@ func (c *Client) RefundCaptureWithPaypalRequestId(ctx context.Context,
 
 // CapturedDetail - https://developer.paypal.com/docs/api/payments/v2/#captures_get
 // Endpoint: GET /v2/payments/captures/ID
-func (c *Client) CapturedDetail(ctx context.Context, captureID string) (*CaptureDetailsResponse, error) {
+func (c *Client) CapturedDetail(ctx context.Context, captureID string, opts ...CallOption) (*CaptureDetailsResponse, error) {
+	ctx = withCallOptions(ctx, opts)
+
 	response := &CaptureDetailsResponse{}
 
 	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s%s", c.APIBase, "/v2/payments/captures/"+captureID), nil)
@@ -0,0 +1,72 @@
+package paypal
+
+import "fmt"
+
+// Fields of a purchase unit which can be removed with PurchaseUnitPatch.Remove
+const (
+	PurchaseUnitFieldDescription     = "description"
+	PurchaseUnitFieldCustomID        = "custom_id"
+	PurchaseUnitFieldInvoiceID       = "invoice_id"
+	PurchaseUnitFieldSoftDescriptor  = "soft_descriptor"
+	PurchaseUnitFieldShippingName    = "shipping/name"
+	PurchaseUnitFieldShippingAddress = "shipping/address"
+)
+
+// PurchaseUnitPatch builds the patches of an order purchase unit, see Client.UpdateOrderPatches
+type PurchaseUnitPatch struct {
+	ReferenceID string
+}
+
+// PatchPurchaseUnit returns a PurchaseUnitPatch addressing the purchase unit by reference ID,
+// orders with a single purchase unit use "default" if no reference ID was given at creation
+func PatchPurchaseUnit(referenceID string) PurchaseUnitPatch {
+	if referenceID == "" {
+		referenceID = "default"
+	}
+	return PurchaseUnitPatch{ReferenceID: referenceID}
+}
+
+// Path returns the JSON pointer of a field of the purchase unit
+func (p PurchaseUnitPatch) Path(field string) string {
+	return fmt.Sprintf("/purchase_units/@reference_id=='%s'/%s", p.ReferenceID, field)
+}
+
+// Amount replaces the amount, its breakdown must match the items and total of the purchase unit
+func (p PurchaseUnitPatch) Amount(amount *PurchaseUnitAmount) Patch {
+	return Patch{Operation: "replace", Path: p.Path("amount"), Value: amount}
+}
+
+// ShippingAddress sets the shipping address
+func (p PurchaseUnitPatch) ShippingAddress(address *ShippingDetailAddressPortable) Patch {
+	return Patch{Operation: "add", Path: p.Path("shipping/address"), Value: address}
+}
+
+// ShippingName sets the name of the recipient
+func (p PurchaseUnitPatch) ShippingName(name *Name) Patch {
+	return Patch{Operation: "add", Path: p.Path("shipping/name"), Value: name}
+}
+
+// Description sets the description
+func (p PurchaseUnitPatch) Description(description string) Patch {
+	return Patch{Operation: "add", Path: p.Path("description"), Value: description}
+}
+
+// CustomID sets the custom ID
+func (p PurchaseUnitPatch) CustomID(customID string) Patch {
+	return Patch{Operation: "add", Path: p.Path("custom_id"), Value: customID}
+}
+
+// InvoiceID sets the invoice ID
+func (p PurchaseUnitPatch) InvoiceID(invoiceID string) Patch {
+	return Patch{Operation: "add", Path: p.Path("invoice_id"), Value: invoiceID}
+}
+
+// Payee replaces the merchant receiving the payment
+func (p PurchaseUnitPatch) Payee(payee *PayeeForOrders) Patch {
+	return Patch{Operation: "replace", Path: p.Path("payee"), Value: payee}
+}
+
+// Remove removes a field, e.g. PurchaseUnitFieldDescription, the patch is sent without value
+func (p PurchaseUnitPatch) Remove(field string) Patch {
+	return Patch{Operation: "remove", Path: p.Path(field)}
+}
@@ -0,0 +1,85 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestUpdateOrder_Patches(t *testing.T) {
+	var body []byte
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/v2/checkout/orders/O-1" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	unit := PatchPurchaseUnit("")
+	err := c.UpdateOrderPatches(context.Background(), "O-1", []Patch{
+		unit.Amount(&PurchaseUnitAmount{
+			Currency: "USD",
+			Value:    "12.00",
+			Breakdown: &PurchaseUnitAmountBreakdown{
+				ItemTotal: &Money{Currency: "USD", Value: "10.00"},
+				Shipping:  &Money{Currency: "USD", Value: "2.00"},
+			},
+		}),
+		unit.ShippingAddress(&ShippingDetailAddressPortable{AddressLine1: "1 Main St", CountryCode: "US"}),
+		unit.Remove(PurchaseUnitFieldDescription),
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var patches []map[string]interface{}
+	if err := json.Unmarshal(body, &patches); err != nil {
+		t.Fatalf("expected JSON patches, got %s", body)
+	}
+	if len(patches) != 3 {
+		t.Fatalf("expected 3 patches, got %s", body)
+	}
+
+	want := []struct{ op, path string }{
+		{"replace", "/purchase_units/@reference_id=='default'/amount"},
+		{"add", "/purchase_units/@reference_id=='default'/shipping/address"},
+		{"remove", "/purchase_units/@reference_id=='default'/description"},
+	}
+	for i, w := range want {
+		if patches[i]["op"] != w.op || patches[i]["path"] != w.path {
+			t.Errorf("patch %d: expected %s %s, got %v", i, w.op, w.path, patches[i])
+		}
+	}
+
+	amount, _ := patches[0]["value"].(map[string]interface{})
+	if breakdown, _ := amount["breakdown"].(map[string]interface{}); breakdown["shipping"] == nil {
+		t.Errorf("expected nested breakdown, got %v", patches[0]["value"])
+	}
+	var raw []json.RawMessage
+	if err := json.Unmarshal(body, &raw); err != nil {
+		t.Fatalf("expected JSON patches, got %s", body)
+	}
+	if strings.Contains(string(raw[2]), `"value"`) {
+		t.Errorf("expected remove without value, got %s", raw[2])
+	}
+}
+
+func TestUpdateOrder(t *testing.T) {
+	var body []byte
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	err := c.UpdateOrder(context.Background(), "O-1", "replace", "/purchase_units/@reference_id=='default'/custom_id", map[string]string{"custom_id": "C-1"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if want := `[{"op":"replace","path":"/purchase_units/@reference_id=='default'/custom_id","value":{"custom_id":"C-1"}}]`; strings.TrimSpace(string(body)) != want {
+		t.Errorf("expected %s, got %s", want, body)
+	}
+}
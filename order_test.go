@@ -0,0 +1,74 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestConfirmOrderPaymentSource(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v2/checkout/orders/O-1/confirm-payment-source" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if mock := r.Header.Get("PayPal-Mock-Response"); mock != `{"mock_application_codes":"INSTRUMENT_DECLINED"}` {
+			t.Errorf("unexpected mock response header %q", mock)
+		}
+
+		var body ConfirmPaymentSourceRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.PaymentSource == nil || body.PaymentSource.Token == nil {
+			t.Errorf("expected token payment source, got %+v (%v)", body, err)
+		}
+		_, _ = w.Write([]byte(`{"id":"O-1","status":"APPROVED","payment_source":{"token":{"id":"T-1","type":"BILLING_AGREEMENT"}}}`))
+	})
+
+	order, err := c.ConfirmOrderPaymentSource(context.Background(), "O-1", ConfirmPaymentSourceRequest{
+		PaymentSource: &PaymentSource{Token: &PaymentSourceToken{ID: "T-1", Type: "BILLING_AGREEMENT"}},
+	}, WithMockResponse(MockResponse{MockApplicationCodes: "INSTRUMENT_DECLINED"}))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if order.Status != "APPROVED" || order.PaymentSource == nil || order.PaymentSource.Token.ID != "T-1" {
+		t.Errorf("unexpected order %+v", order)
+	}
+}
+
+func TestOrderTrackers(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v2/checkout/orders/O-1/track":
+			var body OrderTrackerRequest
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.CaptureID != "C-1" || body.Carrier != CarrierFedEx {
+				t.Errorf("unexpected tracker %+v (%v)", body, err)
+			}
+			_, _ = w.Write([]byte(`{"id":"O-1","status":"COMPLETED","purchase_units":[{"reference_id":"default",
+				"shipping":{"trackers":[{"id":"C-1-443844607820","status":"SHIPPED"}]}}]}`))
+		case r.Method == http.MethodPatch && r.URL.Path == "/v2/checkout/orders/O-1/trackers/C-1-443844607820":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	order, err := c.AddOrderTracker(context.Background(), "O-1", OrderTrackerRequest{
+		CaptureID:      "C-1",
+		TrackingNumber: "443844607820",
+		Carrier:        CarrierFedEx,
+		NotifyPayer:    true,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	trackers := order.PurchaseUnits[0].Shipping.Trackers
+	if len(trackers) != 1 || trackers[0].Status != OrderTrackerStatusShipped {
+		t.Fatalf("unexpected trackers %+v", trackers)
+	}
+
+	err = c.UpdateOrderTracker(context.Background(), "O-1", trackers[0].ID, []Patch{
+		{Operation: "replace", Path: "/status", Value: OrderTrackerStatusCancelled},
+	})
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
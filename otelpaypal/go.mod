@@ -0,0 +1,24 @@
+module github.com/plutov/paypal/v4/otelpaypal
+
+go 1.25.1
+
+require (
+	github.com/plutov/paypal/v4 v4.0.0-20261017204752-4e6bdab9b36f
+	go.opentelemetry.io/otel v1.34.0
+	go.opentelemetry.io/otel/metric v1.34.0
+	go.opentelemetry.io/otel/sdk v1.34.0
+	go.opentelemetry.io/otel/sdk/metric v1.34.0
+	go.opentelemetry.io/otel/trace v1.34.0
+)
+
+require (
+	github.com/go-logr/logr v1.4.2 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	go.opentelemetry.io/auto/sdk v1.1.0 // indirect
+	golang.org/x/sys v0.33.0 // indirect
+)
+
+// Builds against the parent directory during development, consumers ignore replace directives
+// and use the required version above
+replace github.com/plutov/paypal/v4 => ../
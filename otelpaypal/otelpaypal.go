@@ -0,0 +1,223 @@
+// Package otelpaypal instruments a paypal.Client with OpenTelemetry.
+//
+// Every request sent by the client gets a client span named after the API operation,
+// e.g. paypal.orders.capture, and is recorded in the request duration histogram.
+// Failed requests are counted by PayPal issue code, and client_credentials token requests by the token refresh counter.
+//
+//	c, _ := paypal.NewClient(clientID, secret, paypal.APIBaseSandBox)
+//	c.Use(otelpaypal.Middleware())
+package otelpaypal
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/plutov/paypal/v4"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope of the tracer and meter
+const ScopeName = "github.com/plutov/paypal/v4/otelpaypal"
+
+// Attribute keys specific to PayPal
+const (
+	DebugIDKey   = attribute.Key("paypal.debug_id")
+	RequestIDKey = attribute.Key("paypal.request_id")
+	IssueKey     = attribute.Key("paypal.issue")
+	CategoryKey  = attribute.Key("paypal.error_category")
+	OperationKey = attribute.Key("paypal.operation")
+)
+
+// tokenOperation is the operation name of access token requests
+const tokenOperation = "paypal.oauth2.token"
+
+type (
+	// Option configures the instrumentation
+	Option func(*config)
+
+	config struct {
+		tracerProvider trace.TracerProvider
+		meterProvider  metric.MeterProvider
+		propagators    propagation.TextMapPropagator
+	}
+
+	instruments struct {
+		duration       metric.Float64Histogram
+		errors         metric.Int64Counter
+		tokenRefreshes metric.Int64Counter
+	}
+)
+
+// WithTracerProvider sets the TracerProvider, the global one is used by default
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = provider
+	}
+}
+
+// WithMeterProvider sets the MeterProvider, the global one is used by default
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = provider
+	}
+}
+
+// WithPropagators sets the propagators injecting the span context into request headers,
+// the global ones are used by default
+func WithPropagators(propagators propagation.TextMapPropagator) Option {
+	return func(c *config) {
+		c.propagators = propagators
+	}
+}
+
+// Middleware returns a paypal.Middleware creating a span and recording metrics for every request.
+// Spans are children of the span in the context passed to the client methods.
+func Middleware(opts ...Option) paypal.Middleware {
+	cfg := config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+		propagators:    otel.GetTextMapPropagator(),
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	tracer := cfg.tracerProvider.Tracer(ScopeName)
+	inst := newInstruments(cfg.meterProvider.Meter(ScopeName))
+
+	return func(next paypal.RoundTripFunc) paypal.RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			operation := paypal.OperationName(req)
+
+			ctx, span := tracer.Start(req.Context(), operation,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(
+					OperationKey.String(operation),
+					attribute.String("http.request.method", req.Method),
+					attribute.String("url.path", req.URL.Path),
+					attribute.String("server.address", req.URL.Hostname()),
+				),
+			)
+			defer span.End()
+
+			req = req.WithContext(ctx)
+			cfg.propagators.Inject(ctx, propagation.HeaderCarrier(req.Header))
+			if requestID := req.Header.Get("PayPal-Request-Id"); requestID != "" {
+				span.SetAttributes(RequestIDKey.String(requestID))
+			}
+
+			refresh := operation == tokenOperation && grantType(req) == "client_credentials"
+
+			start := time.Now()
+			resp, err := next(req)
+			duration := time.Since(start)
+
+			attrs := []attribute.KeyValue{OperationKey.String(operation)}
+
+			var errResp *paypal.ErrorResponse
+			statusResp := resp
+			if errors.As(err, &errResp) && errResp.Response != nil {
+				statusResp = errResp.Response
+			}
+			if statusResp != nil {
+				status := attribute.Int("http.response.status_code", statusResp.StatusCode)
+				attrs = append(attrs, status)
+				span.SetAttributes(status)
+
+				debugID := statusResp.Header.Get("Paypal-Debug-Id")
+				if debugID == "" && errResp != nil {
+					debugID = errResp.DebugID
+				}
+				if debugID != "" {
+					span.SetAttributes(DebugIDKey.String(debugID))
+				}
+			}
+
+			if err != nil {
+				errAttrs := []attribute.KeyValue{OperationKey.String(operation)}
+				if errResp != nil {
+					issue := attribute.String(string(IssueKey), errResp.Issue())
+					category := attribute.String(string(CategoryKey), string(errResp.Category()))
+					span.SetAttributes(issue, category)
+					errAttrs = append(errAttrs, issue, category)
+				} else {
+					errAttrs = append(errAttrs, attribute.String("error.type", "transport"))
+				}
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+				inst.errors.Add(ctx, 1, metric.WithAttributes(errAttrs...))
+			}
+
+			inst.duration.Record(ctx, duration.Seconds(), metric.WithAttributes(attrs...))
+			if refresh {
+				inst.tokenRefreshes.Add(ctx, 1, metric.WithAttributes(attribute.Bool("error", err != nil)))
+			}
+
+			return resp, err
+		}
+	}
+}
+
+// Instrument registers the Middleware on the client
+func Instrument(c *paypal.Client, opts ...Option) {
+	c.Use(Middleware(opts...))
+}
+
+// grantType returns the grant_type of a token request, "" if its body can't be read again
+func grantType(req *http.Request) string {
+	if req.GetBody == nil {
+		return ""
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer func() {
+		_ = body.Close()
+	}()
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return ""
+	}
+	form, err := url.ParseQuery(string(data))
+	if err != nil {
+		return ""
+	}
+	return form.Get("grant_type")
+}
+
+// newInstruments creates the metric instruments, errors are reported to the global OpenTelemetry error handler
+func newInstruments(meter metric.Meter) instruments {
+	duration, err := meter.Float64Histogram("paypal.client.request.duration",
+		metric.WithDescription("Duration of requests sent to PayPal"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+	errs, err := meter.Int64Counter("paypal.client.request.errors",
+		metric.WithDescription("Number of failed requests by PayPal issue code"),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+	tokenRefreshes, err := meter.Int64Counter("paypal.client.token.refreshes",
+		metric.WithDescription("Number of client credentials access token requests"),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return instruments{duration: duration, errors: errs, tokenRefreshes: tokenRefreshes}
+}
@@ -0,0 +1,133 @@
+package otelpaypal
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/plutov/paypal/v4"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func newInstrumentedClient(t *testing.T, handler http.HandlerFunc) (*paypal.Client, *tracetest.InMemoryExporter, *sdkmetric.ManualReader) {
+	t.Helper()
+
+	ts := httptest.NewServer(handler)
+	t.Cleanup(ts.Close)
+
+	c, err := paypal.NewClient("clientID", "secret", ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exporter := tracetest.NewInMemoryExporter()
+	reader := sdkmetric.NewManualReader()
+	Instrument(c,
+		WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))),
+		WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+	)
+
+	return c, exporter, reader
+}
+
+func spanAttribute(span tracetest.SpanStub, key attribute.Key) attribute.Value {
+	for _, attr := range span.Attributes {
+		if attr.Key == key {
+			return attr.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func sumOf(t *testing.T, reader *sdkmetric.ManualReader, name string) int64 {
+	t.Helper()
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+	var total int64
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != name {
+				continue
+			}
+			for _, point := range m.Data.(metricdata.Sum[int64]).DataPoints {
+				total += point.Value
+			}
+		}
+	}
+	return total
+}
+
+func TestMiddleware_Spans(t *testing.T) {
+	c, exporter, reader := newInstrumentedClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/oauth2/token" {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"access_token":"token","expires_in":3600}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Paypal-Debug-Id", "debug-1")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"name":"UNPROCESSABLE_ENTITY","debug_id":"debug-1","details":[{"issue":"ORDER_NOT_APPROVED"}]}`))
+	})
+
+	tracer := sdktrace.NewTracerProvider().Tracer("test")
+	ctx, parent := tracer.Start(context.Background(), "parent")
+	_, err := c.CaptureOrder(ctx, "ORDER-1", paypal.CaptureOrderRequest{})
+	parent.End()
+
+	if !errors.Is(err, paypal.ErrOrderNotApproved) {
+		t.Fatalf("expected ErrOrderNotApproved, got %v", err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+	token, capture := spans[0], spans[1]
+	if token.Name != "paypal.oauth2.token" {
+		t.Errorf("expected token span, got %s", token.Name)
+	}
+	if capture.Name != "paypal.orders.capture" {
+		t.Errorf("expected capture span, got %s", capture.Name)
+	}
+	if capture.Parent.TraceID() != parent.SpanContext().TraceID() {
+		t.Errorf("expected span in the caller trace")
+	}
+	if capture.Status.Code != codes.Error {
+		t.Errorf("expected error status, got %v", capture.Status.Code)
+	}
+	if v := spanAttribute(capture, "http.response.status_code").AsInt64(); v != http.StatusUnprocessableEntity {
+		t.Errorf("unexpected status %d", v)
+	}
+	if v := spanAttribute(capture, DebugIDKey).AsString(); v != "debug-1" {
+		t.Errorf("unexpected debug id %q", v)
+	}
+	if v := spanAttribute(capture, IssueKey).AsString(); v != "ORDER_NOT_APPROVED" {
+		t.Errorf("unexpected issue %q", v)
+	}
+
+	if n := sumOf(t, reader, "paypal.client.request.errors"); n != 1 {
+		t.Errorf("expected 1 error, got %d", n)
+	}
+	if n := sumOf(t, reader, "paypal.client.token.refreshes"); n != 1 {
+		t.Errorf("expected 1 token refresh, got %d", n)
+	}
+
+	// Exchanging an authorization code is not a refresh of the client token
+	if _, err := c.ExchangeAuthorizationCode(context.Background(), "code-1"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if n := sumOf(t, reader, "paypal.client.token.refreshes"); n != 1 {
+		t.Errorf("expected authorization code exchanges not to count, got %d token refreshes", n)
+	}
+}
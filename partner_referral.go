@@ -0,0 +1,70 @@
+package paypal
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// CreatePartnerReferral creates a partner referral to onboard a seller.
+// Redirect the seller to the ActionURL of the response to sign up or log in to PayPal.
+// Endpoint: POST /v2/customer/partner-referrals
+func (c *Client) CreatePartnerReferral(ctx context.Context, referral ReferralRequest, opts ...CallOption) (*ReferralResponse, error) {
+	ctx = withCallOptions(ctx, opts)
+
+	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s", c.APIBase, "/v2/customer/partner-referrals"), referral)
+	response := &ReferralResponse{}
+
+	if err != nil {
+		return response, err
+	}
+
+	if err = c.SendWithAuth(req, response); err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// GetPartnerReferral shows the data of a partner referral
+// Endpoint: GET /v2/customer/partner-referrals/ID
+func (c *Client) GetPartnerReferral(ctx context.Context, partnerReferralID string, opts ...CallOption) (*PartnerReferral, error) {
+	ctx = withCallOptions(ctx, opts)
+
+	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s%s", c.APIBase, "/v2/customer/partner-referrals/"+partnerReferralID), nil)
+	response := &PartnerReferral{}
+
+	if err != nil {
+		return response, err
+	}
+
+	if err = c.SendWithAuth(req, response); err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// ActionURL returns the URL the seller must be redirected to, empty if the response has no action_url link
+func (r *ReferralResponse) ActionURL() string {
+	return findLink(r.Links, LinkRelActionURL)
+}
+
+// PartnerReferralID returns the ID of the created partner referral, taken from its self link
+func (r *ReferralResponse) PartnerReferralID() string {
+	self := findLink(r.Links, LinkRelSelf)
+	if self == "" {
+		return ""
+	}
+	return self[strings.LastIndex(self, "/")+1:]
+}
+
+// findLink returns the href of the first link with the relation rel
+func findLink(links []Link, rel string) string {
+	for _, link := range links {
+		if link.Rel == rel {
+			return link.Href
+		}
+	}
+	return ""
+}
@@ -0,0 +1,53 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestCreatePartnerReferral(t *testing.T) {
+	var received ReferralRequest
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "POST /v2/customer/partner-referrals":
+			_ = json.NewDecoder(r.Body).Decode(&received)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"links":[
+				{"href":"https://api-m.sandbox.paypal.com/v2/customer/partner-referrals/REF-1","rel":"self","method":"GET"},
+				{"href":"https://www.sandbox.paypal.com/bizsignup/partner/entry?referralToken=TOKEN","rel":"action_url","method":"GET"}
+			]}`))
+		case "GET /v2/customer/partner-referrals/REF-1":
+			_, _ = w.Write([]byte(`{"partner_referral_id":"REF-1","submitter_payer_id":"PAYER-1","referral_data":{"tracking_id":"seller-1"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	referral, err := c.CreatePartnerReferral(context.Background(), ReferralRequest{
+		TrackingID: "seller-1",
+		Operations: []Operation{{Operation: OperationAPIIntegration}},
+		Products:   []string{ProductExpressCheckout},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if received.TrackingID != "seller-1" {
+		t.Errorf("unexpected request %+v", received)
+	}
+	if referral.ActionURL() != "https://www.sandbox.paypal.com/bizsignup/partner/entry?referralToken=TOKEN" {
+		t.Errorf("unexpected action url %q", referral.ActionURL())
+	}
+	if referral.PartnerReferralID() != "REF-1" {
+		t.Errorf("unexpected partner referral id %q", referral.PartnerReferralID())
+	}
+
+	data, err := c.GetPartnerReferral(context.Background(), referral.PartnerReferralID())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if data.ReferralData.TrackingID != "seller-1" || data.SubmitterPayerID != "PAYER-1" {
+		t.Errorf("unexpected partner referral %+v", data)
+	}
+}
@@ -0,0 +1,190 @@
+package paypal
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// PaymentNodeType is the kind of resource of a PaymentNode
+type PaymentNodeType string
+
+// Possible values of PaymentNodeType
+const (
+	PaymentNodeOrder         PaymentNodeType = "order"
+	PaymentNodeAuthorization PaymentNodeType = "authorization"
+	PaymentNodeCapture       PaymentNodeType = "capture"
+	PaymentNodeRefund        PaymentNodeType = "refund"
+)
+
+// PaymentNode is a resource of the payment tree of an order, see GetPaymentTree
+type PaymentNode struct {
+	Type       PaymentNodeType
+	ID         string
+	Status     string
+	Amount     *Money
+	CreateTime *time.Time
+	UpdateTime *time.Time
+	Links      []Link
+	Children   []*PaymentNode
+}
+
+// Walk calls fn for the node and all its descendants, parents first
+func (n *PaymentNode) Walk(fn func(node *PaymentNode)) {
+	fn(n)
+	for _, child := range n.Children {
+		child.Walk(fn)
+	}
+}
+
+// Find returns the node with the given ID, nil if it is not in the tree
+func (n *PaymentNode) Find(id string) *PaymentNode {
+	var found *PaymentNode
+	n.Walk(func(node *PaymentNode) {
+		if found == nil && node.ID == id {
+			found = node
+		}
+	})
+	return found
+}
+
+// GetPaymentTree returns the order with its authorizations, captures and refunds as a tree:
+// captures are children of their authorization, or of the order for orders with intent CAPTURE,
+// and refunds are children of their capture.
+// Every payment is fetched from its self link for an up to date status, its parent is found from its up link.
+func (c *Client) GetPaymentTree(ctx context.Context, orderID string, opts ...CallOption) (*PaymentNode, error) {
+	ctx = withCallOptions(ctx, opts)
+
+	order, err := c.GetOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	root := &PaymentNode{
+		Type:       PaymentNodeOrder,
+		ID:         order.ID,
+		Status:     order.Status,
+		CreateTime: order.CreateTime,
+		UpdateTime: order.UpdateTime,
+		Links:      order.Links,
+	}
+	if len(order.PurchaseUnits) == 1 {
+		root.Amount = purchaseUnitMoney(order.PurchaseUnits[0].Amount)
+	}
+
+	nodes := map[string]*PaymentNode{}
+	attach := func(node *PaymentNode) {
+		parent := root
+		if up := findLink(node.Links, "up"); up != "" {
+			if p, ok := nodes[paymentResourceKey(up)]; ok {
+				parent = p
+			}
+		}
+		parent.Children = append(parent.Children, node)
+		nodes[paymentResourceKey(fmt.Sprintf("/%ss/%s", node.Type, node.ID))] = node
+	}
+
+	for _, unit := range order.PurchaseUnits {
+		if unit.Payments == nil {
+			continue
+		}
+
+		for _, authorization := range unit.Payments.Authorizations {
+			auth := &Authorization{}
+			if err := c.getPaymentResource(ctx, authorization.Links, "/v2/payments/authorizations/"+authorization.ID, auth); err != nil {
+				return nil, err
+			}
+			attach(&PaymentNode{
+				Type:       PaymentNodeAuthorization,
+				ID:         auth.ID,
+				Status:     auth.Status,
+				Amount:     purchaseUnitMoney(auth.Amount),
+				CreateTime: auth.CreateTime,
+				UpdateTime: auth.UpdateTime,
+				Links:      auth.Links,
+			})
+		}
+
+		for _, captureAmount := range unit.Payments.Captures {
+			capture := &CaptureDetailsResponse{}
+			if err := c.getPaymentResource(ctx, captureAmount.Links, "/v2/payments/captures/"+captureAmount.ID, capture); err != nil {
+				return nil, err
+			}
+			attach(&PaymentNode{
+				Type:       PaymentNodeCapture,
+				ID:         capture.ID,
+				Status:     capture.Status,
+				Amount:     capture.Amount,
+				CreateTime: capture.CreateTime,
+				UpdateTime: capture.UpdateTime,
+				Links:      capture.Links,
+			})
+		}
+
+		for _, refundResponse := range unit.Payments.Refunds {
+			refund := &RefundResponse{}
+			if err := c.getPaymentResource(ctx, refundResponse.Links, "/v2/payments/refunds/"+refundResponse.ID, refund); err != nil {
+				return nil, err
+			}
+			attach(&PaymentNode{
+				Type:       PaymentNodeRefund,
+				ID:         refund.ID,
+				Status:     refund.Status,
+				Amount:     purchaseUnitMoney(refund.Amount),
+				CreateTime: refund.CreateTime,
+				UpdateTime: refund.UpdateTime,
+				Links:      refund.Links,
+			})
+		}
+	}
+
+	return root, nil
+}
+
+// getPaymentResource gets a payment from its self link, or from path if it has none
+func (c *Client) getPaymentResource(ctx context.Context, links []Link, path string, v interface{}) error {
+	href := findLink(links, "self")
+	if href == "" {
+		href = path
+	}
+
+	return c.getLink(ctx, href, v)
+}
+
+// getLink gets the resource of a HATEOAS link. Only the path and query of the link are sent to APIBase,
+// so a link pointing to another host never receives the access token.
+func (c *Client) getLink(ctx context.Context, href string, v interface{}) error {
+	link, err := url.Parse(href)
+	if err != nil {
+		return err
+	}
+	target := c.APIBase + link.EscapedPath()
+	if link.RawQuery != "" {
+		target += "?" + link.RawQuery
+	}
+
+	req, err := c.NewRequest(ctx, "GET", target, nil)
+	if err != nil {
+		return err
+	}
+
+	return c.SendWithAuth(req, v)
+}
+
+// paymentResourceKey returns the last two segments of the URL of a payment, e.g. captures/ID
+func paymentResourceKey(href string) string {
+	segments := strings.Split(strings.TrimSuffix(href, "/"), "/")
+	if len(segments) < 2 {
+		return href
+	}
+	return segments[len(segments)-2] + "/" + segments[len(segments)-1]
+}
+
+func purchaseUnitMoney(amount *PurchaseUnitAmount) *Money {
+	if amount == nil {
+		return nil
+	}
+	return &Money{Currency: amount.Currency, Value: amount.Value}
+}
@@ -0,0 +1,112 @@
+package paypal
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetPaymentTree(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		base := "http://" + r.Host
+		switch r.URL.Path {
+		case "/v2/checkout/orders/O-1":
+			fmt.Fprintf(w, `{"id":"O-1","status":"COMPLETED","purchase_units":[{"reference_id":"default",
+				"amount":{"currency_code":"USD","value":"100.00"},
+				"payments":{
+					"authorizations":[{"id":"A-1","links":[{"rel":"self","href":"%[1]s/v2/payments/authorizations/A-1"}]}],
+					"captures":[{"id":"C-1","links":[{"rel":"self","href":"%[1]s/v2/payments/captures/C-1"}]}],
+					"refunds":[{"id":"R-1","links":[{"rel":"self","href":"%[1]s/v2/payments/refunds/R-1"}]}]
+				}}]}`, base)
+		case "/v2/payments/authorizations/A-1":
+			fmt.Fprintf(w, `{"id":"A-1","status":"CAPTURED","amount":{"currency_code":"USD","value":"100.00"},
+				"links":[{"rel":"up","href":"%s/v2/checkout/orders/O-1"}]}`, base)
+		case "/v2/payments/captures/C-1":
+			fmt.Fprintf(w, `{"id":"C-1","status":"PARTIALLY_REFUNDED","amount":{"currency_code":"USD","value":"100.00"},
+				"links":[{"rel":"up","href":"%s/v2/payments/authorizations/A-1"}]}`, base)
+		case "/v2/payments/refunds/R-1":
+			fmt.Fprintf(w, `{"id":"R-1","status":"COMPLETED","amount":{"currency_code":"USD","value":"30.00"},
+				"links":[{"rel":"up","href":"%s/v2/payments/captures/C-1"}]}`, base)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	tree, err := c.GetPaymentTree(context.Background(), "O-1")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if tree.Type != PaymentNodeOrder || tree.Amount.Value != "100.00" || len(tree.Children) != 1 {
+		t.Fatalf("unexpected order node %+v", tree)
+	}
+
+	auth := tree.Children[0]
+	if auth.ID != "A-1" || auth.Status != "CAPTURED" || len(auth.Children) != 1 {
+		t.Fatalf("unexpected authorization node %+v", auth)
+	}
+	capture := auth.Children[0]
+	if capture.ID != "C-1" || capture.Status != "PARTIALLY_REFUNDED" || len(capture.Children) != 1 {
+		t.Fatalf("unexpected capture node %+v", capture)
+	}
+	refund := tree.Find("R-1")
+	if refund != capture.Children[0] || refund.Type != PaymentNodeRefund || refund.Amount.Value != "30.00" {
+		t.Errorf("unexpected refund node %+v", refund)
+	}
+}
+
+func TestGetRefund(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/payments/refunds/R-1" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"id":"R-1","status":"COMPLETED","amount":{"currency_code":"USD","value":"30.00"}}`))
+	})
+
+	refund, err := c.GetRefundDetails(context.Background(), "R-1")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if refund.Status != "COMPLETED" || refund.Amount.Value != "30.00" {
+		t.Errorf("unexpected refund %+v", refund)
+	}
+
+	legacy, err := c.GetRefund(context.Background(), "R-1")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if legacy.ID != "R-1" {
+		t.Errorf("unexpected refund %+v", legacy)
+	}
+}
+
+func TestGetPaymentTree_LinksToOtherHosts(t *testing.T) {
+	foreign := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to another host %s with Authorization %q", r.URL.Path, r.Header.Get("Authorization"))
+	}))
+	defer foreign.Close()
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/checkout/orders/O-1":
+			fmt.Fprintf(w, `{"id":"O-1","status":"COMPLETED","purchase_units":[{"payments":{
+				"captures":[{"id":"C-1","links":[{"rel":"self","href":"%s/v2/payments/captures/C-1"}]}]
+			}}]}`, foreign.URL)
+		case "/v2/payments/captures/C-1":
+			_, _ = w.Write([]byte(`{"id":"C-1","status":"COMPLETED"}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	tree, err := c.GetPaymentTree(context.Background(), "O-1")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if capture := tree.Find("C-1"); capture == nil || capture.Status != "COMPLETED" {
+		t.Errorf("expected the capture to be fetched from APIBase, got %+v", capture)
+	}
+}
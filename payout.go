@@ -8,7 +8,9 @@ import (
 // CreatePayout submits a payout with an asynchronous API call, which immediately returns the results of a PayPal payment.
 // For email payout set RecipientType: "EMAIL" and receiver email into Receiver
 // Endpoint: POST /v1/payments/payouts
-func (c *Client) CreatePayout(ctx context.Context, p Payout) (*PayoutResponse, error) {
+func (c *Client) CreatePayout(ctx context.Context, p Payout, opts ...CallOption) (*PayoutResponse, error) {
+	ctx = withCallOptions(ctx, opts)
+
 	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s", c.APIBase, "/v1/payments/payouts"), p)
 	response := &PayoutResponse{}
 
@@ -26,7 +28,9 @@ func (c *Client) CreatePayout(ctx context.Context, p Payout) (*PayoutResponse, e
 // GetPayout shows the latest status of a batch payout along with the transaction status and other data for individual items.
 // Also, returns IDs for the individual payout items. You can use these item IDs in other calls.
 // Endpoint: GET /v1/payments/payouts/ID
-func (c *Client) GetPayout(ctx context.Context, payoutBatchID string) (*PayoutResponse, error) {
+func (c *Client) GetPayout(ctx context.Context, payoutBatchID string, opts ...CallOption) (*PayoutResponse, error) {
+	ctx = withCallOptions(ctx, opts)
+
 	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s%s", c.APIBase, "/v1/payments/payouts/"+payoutBatchID), nil)
 	response := &PayoutResponse{}
 
@@ -44,7 +48,9 @@ func (c *Client) GetPayout(ctx context.Context, payoutBatchID string) (*PayoutRe
 // GetPayoutItem shows the details for a payout item.
 // Use this call to review the current status of a previously unclaimed, or pending, payout item.
 // Endpoint: GET /v1/payments/payouts-item/ID
-func (c *Client) GetPayoutItem(ctx context.Context, payoutItemID string) (*PayoutItemResponse, error) {
+func (c *Client) GetPayoutItem(ctx context.Context, payoutItemID string, opts ...CallOption) (*PayoutItemResponse, error) {
+	ctx = withCallOptions(ctx, opts)
+
 	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s%s", c.APIBase, "/v1/payments/payouts-item/"+payoutItemID), nil)
 	response := &PayoutItemResponse{}
 
@@ -62,7 +68,9 @@ func (c *Client) GetPayoutItem(ctx context.Context, payoutItemID string) (*Payou
 // CancelPayoutItem cancels an unclaimed Payout Item. If no one claims the unclaimed item within 30 days,
 // the funds are automatically returned to the sender. Use this call to cancel the unclaimed item before the automatic 30-day refund.
 // Endpoint: POST /v1/payments/payouts-item/ID/cancel
-func (c *Client) CancelPayoutItem(ctx context.Context, payoutItemID string) (*PayoutItemResponse, error) {
+func (c *Client) CancelPayoutItem(ctx context.Context, payoutItemID string, opts ...CallOption) (*PayoutItemResponse, error) {
+	ctx = withCallOptions(ctx, opts)
+
 	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s", c.APIBase, "/v1/payments/payouts-item/"+payoutItemID+"/cancel"), nil)
 	response := &PayoutItemResponse{}
 
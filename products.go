@@ -61,7 +61,9 @@ func (p *Product) GetUpdatePatch() []Patch {
 // CreateProduct creates a product
 // Doc: https://developer.paypal.com/docs/api/catalog-products/v1/#products_create
 // Endpoint: POST /v1/catalogs/products
-func (c *Client) CreateProduct(ctx context.Context, product Product) (*CreateProductResponse, error) {
+func (c *Client) CreateProduct(ctx context.Context, product Product, opts ...CallOption) (*CreateProductResponse, error) {
+	ctx = withCallOptions(ctx, opts)
+
 	req, err := c.NewRequest(ctx, http.MethodPost, fmt.Sprintf("%s%s", c.APIBase, "/v1/catalogs/products"), product)
 	response := &CreateProductResponse{}
 	if err != nil {
@@ -74,7 +76,9 @@ func (c *Client) CreateProduct(ctx context.Context, product Product) (*CreatePro
 // UpdateProduct. updates a product information
 // Doc: https://developer.paypal.com/docs/api/catalog-products/v1/#products_patch
 // Endpoint: PATCH /v1/catalogs/products/:product_id
-func (c *Client) UpdateProduct(ctx context.Context, product Product) error {
+func (c *Client) UpdateProduct(ctx context.Context, product Product, opts ...CallOption) error {
+	ctx = withCallOptions(ctx, opts)
+
 	req, err := c.NewRequest(ctx, http.MethodPatch, fmt.Sprintf("%s%s%s", c.APIBase, "/v1/catalogs/products/", product.ID), product.GetUpdatePatch())
 	if err != nil {
 		return err
@@ -86,7 +90,9 @@ func (c *Client) UpdateProduct(ctx context.Context, product Product) error {
 // Get product details
 // Doc: https://developer.paypal.com/docs/api/catalog-products/v1/#products_get
 // Endpoint: GET /v1/catalogs/products/:product_id
-func (c *Client) GetProduct(ctx context.Context, productId string) (*Product, error) {
+func (c *Client) GetProduct(ctx context.Context, productId string, opts ...CallOption) (*Product, error) {
+	ctx = withCallOptions(ctx, opts)
+
 	req, err := c.NewRequest(ctx, http.MethodGet, fmt.Sprintf("%s%s%s", c.APIBase, "/v1/catalogs/products/", productId), nil)
 	response := &Product{}
 	if err != nil {
@@ -99,7 +105,9 @@ func (c *Client) GetProduct(ctx context.Context, productId string) (*Product, er
 // List all products
 // Doc: https://developer.paypal.com/docs/api/catalog-products/v1/#products_list
 // Endpoint: GET /v1/catalogs/products
-func (c *Client) ListProducts(ctx context.Context, params *ProductListParameters) (*ListProductsResponse, error) {
+func (c *Client) ListProducts(ctx context.Context, params *ProductListParameters, opts ...CallOption) (*ListProductsResponse, error) {
+	ctx = withCallOptions(ctx, opts)
+
 	req, err := c.NewRequest(ctx, http.MethodGet, fmt.Sprintf("%s%s", c.APIBase, "/v1/catalogs/products"), nil)
 	response := &ListProductsResponse{}
 	if err != nil {
@@ -0,0 +1,184 @@
+package paypal
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// API families throttled separately by PayPal
+const (
+	APIFamilyReporting = "/v1/reporting"
+	APIFamilyDisputes  = "/v1/customer/disputes"
+	APIFamilyPayouts   = "/v1/payments/payouts"
+)
+
+type (
+	// RateLimit is a token bucket: Rate requests per second on average, with bursts of at most Burst requests.
+	// A zero Rate means no limit.
+	RateLimit struct {
+		Rate  float64
+		Burst int
+	}
+
+	// RateLimiter delays requests to stay within a global RateLimit and the RateLimit of their API family.
+	// It can be shared by clients using the same credentials.
+	RateLimiter struct {
+		global   *tokenBucket
+		families map[string]*tokenBucket
+		// now is time.Now, replaced in tests
+		now func() time.Time
+	}
+
+	tokenBucket struct {
+		mu           sync.Mutex
+		limit        RateLimit
+		tokens       float64
+		last         time.Time
+		blockedUntil time.Time
+	}
+)
+
+// NewRateLimiter returns a RateLimiter with a global limit and limits per API family.
+// Families are path prefixes, e.g. APIFamilyReporting, a request counts against the family with the longest matching prefix.
+//
+//	limiter := paypal.NewRateLimiter(paypal.RateLimit{Rate: 50, Burst: 50}, map[string]paypal.RateLimit{
+//		paypal.APIFamilyReporting: {Rate: 0.5, Burst: 1},
+//	})
+func NewRateLimiter(global RateLimit, families map[string]RateLimit) *RateLimiter {
+	now := time.Now()
+	l := &RateLimiter{
+		global:   newTokenBucket(global, now),
+		families: make(map[string]*tokenBucket, len(families)),
+		now:      time.Now,
+	}
+	for family, limit := range families {
+		l.families[family] = newTokenBucket(limit, now)
+	}
+
+	return l
+}
+
+// SetRateLimiter makes the client wait for the limiter before sending every request, retries included.
+// After a 429 response with Retry-After, requests of the same family wait until PayPal accepts them again.
+func (c *Client) SetRateLimiter(limiter *RateLimiter) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.rateLimiter = limiter
+}
+
+// Wait blocks until the request can be sent, or returns the error of ctx
+func (l *RateLimiter) Wait(ctx context.Context, req *http.Request) error {
+	family := l.family(req.URL.Path)
+	if family != nil {
+		if err := family.wait(ctx, l.now); err != nil {
+			return err
+		}
+	}
+	if err := l.global.wait(ctx, l.now); err != nil {
+		// The request is not sent, its family token can be used by another one
+		if family != nil {
+			family.release()
+		}
+		return err
+	}
+	return nil
+}
+
+// observe blocks the family of the request until the Retry-After of a 429 response elapsed
+func (l *RateLimiter) observe(req *http.Request, resp *http.Response) {
+	if resp == nil || resp.StatusCode != http.StatusTooManyRequests {
+		return
+	}
+	after, ok := parseRetryAfter(resp.Header.Get("Retry-After"))
+	if !ok {
+		return
+	}
+
+	bucket := l.family(req.URL.Path)
+	if bucket == nil {
+		bucket = l.global
+	}
+	bucket.block(l.now().Add(after))
+}
+
+// family returns the bucket of the longest family prefix of path, nil if there is none
+func (l *RateLimiter) family(path string) *tokenBucket {
+	var (
+		bucket  *tokenBucket
+		longest int
+	)
+	for prefix, b := range l.families {
+		if len(prefix) > longest && (path == prefix || strings.HasPrefix(path, strings.TrimSuffix(prefix, "/")+"/")) {
+			bucket, longest = b, len(prefix)
+		}
+	}
+	return bucket
+}
+
+func newTokenBucket(limit RateLimit, now time.Time) *tokenBucket {
+	if limit.Burst < 1 {
+		limit.Burst = 1
+	}
+	return &tokenBucket{limit: limit, tokens: float64(limit.Burst), last: now}
+}
+
+// reserve takes a token at now, or returns how long to wait before trying again
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if now.Before(b.blockedUntil) {
+		return b.blockedUntil.Sub(now)
+	}
+	if b.limit.Rate <= 0 {
+		return 0
+	}
+
+	b.tokens = min(b.tokens+now.Sub(b.last).Seconds()*b.limit.Rate, float64(b.limit.Burst))
+	b.last = now
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / b.limit.Rate * float64(time.Second))
+}
+
+func (b *tokenBucket) wait(ctx context.Context, now func() time.Time) error {
+	for {
+		delay := b.reserve(now())
+		if delay <= 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// release gives back a token taken by reserve
+func (b *tokenBucket) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.limit.Rate > 0 {
+		b.tokens = min(b.tokens+1, float64(b.limit.Burst))
+	}
+}
+
+func (b *tokenBucket) block(until time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if until.After(b.blockedUntil) {
+		b.blockedUntil = until
+	}
+}
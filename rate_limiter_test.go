@@ -0,0 +1,147 @@
+package paypal
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeClock is a clock which only moves when advanced
+type fakeClock struct {
+	now atomic.Int64
+}
+
+func (c *fakeClock) Now() time.Time {
+	return time.Unix(0, c.now.Load())
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.now.Add(int64(d))
+}
+
+// newFakeClockRateLimiter returns a RateLimiter using a fake clock, its buckets only refill when the clock is advanced
+func newFakeClockRateLimiter(global RateLimit, families map[string]RateLimit) (*RateLimiter, *fakeClock) {
+	clock := &fakeClock{}
+	limiter := NewRateLimiter(global, families)
+	limiter.now = clock.Now
+	limiter.global.last = clock.Now()
+	for _, bucket := range limiter.families {
+		bucket.last = clock.Now()
+	}
+	return limiter, clock
+}
+
+func TestRateLimiter_FamilyBudget(t *testing.T) {
+	limiter, clock := newFakeClockRateLimiter(RateLimit{}, map[string]RateLimit{
+		APIFamilyReporting: {Rate: 20, Burst: 1},
+	})
+
+	reporting := limiter.family("/v1/reporting/transactions")
+	if reporting == nil {
+		t.Fatal("expected the reporting family to be limited")
+	}
+	if delay := reporting.reserve(clock.Now()); delay != 0 {
+		t.Errorf("expected the burst to be available, got a delay of %s", delay)
+	}
+	if delay := reporting.reserve(clock.Now()); delay != 50*time.Millisecond {
+		t.Errorf("expected to wait 50ms for the next token, got %s", delay)
+	}
+	clock.Advance(50 * time.Millisecond)
+	if delay := reporting.reserve(clock.Now()); delay != 0 {
+		t.Errorf("expected a token after 50ms, got a delay of %s", delay)
+	}
+
+	// Other families are not limited
+	if limiter.family("/v2/checkout/orders/O-1") != nil {
+		t.Error("expected orders not to have a family limit")
+	}
+	for range 3 {
+		if delay := limiter.global.reserve(clock.Now()); delay != 0 {
+			t.Errorf("expected no global limit, got a delay of %s", delay)
+		}
+	}
+}
+
+func TestRateLimiter_GlobalWaitKeepsFamilyToken(t *testing.T) {
+	limiter, clock := newFakeClockRateLimiter(RateLimit{Rate: 1, Burst: 1}, map[string]RateLimit{
+		APIFamilyReporting: {Rate: 1, Burst: 1},
+	})
+	if delay := limiter.global.reserve(clock.Now()); delay != 0 {
+		t.Fatalf("expected the global burst to be available, got a delay of %s", delay)
+	}
+
+	req, _ := http.NewRequest("GET", "https://api-m.paypal.com/v1/reporting/transactions", nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := limiter.Wait(ctx, req); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the global wait to stop with the context, got %v", err)
+	}
+	if delay := limiter.family(req.URL.Path).reserve(clock.Now()); delay != 0 {
+		t.Errorf("expected the family token to be given back, got a delay of %s", delay)
+	}
+}
+
+func TestRateLimiter_Client(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	})
+	limiter, _ := newFakeClockRateLimiter(RateLimit{}, map[string]RateLimit{
+		APIFamilyReporting: {Rate: 20, Burst: 1},
+	})
+	c.SetRateLimiter(limiter)
+
+	if _, err := c.ListTransactions(context.Background(), &TransactionSearchRequest{}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	for range 3 {
+		if _, err := c.GetOrder(context.Background(), "O-1"); err != nil {
+			t.Fatalf("expected orders calls not to be throttled, got %v", err)
+		}
+	}
+
+	// The clock does not move, the next reporting call waits until the context ends
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := c.ListTransactions(ctx, &TransactionSearchRequest{}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the wait to stop with the context, got %v", err)
+	}
+}
+
+func TestRateLimiter_RetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"name":"RATE_LIMIT_REACHED"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	})
+	limiter, clock := newFakeClockRateLimiter(RateLimit{}, map[string]RateLimit{APIFamilyPayouts: {}})
+	c.SetRateLimiter(limiter)
+
+	if _, err := c.GetPayout(context.Background(), "B-1"); !IsRateLimited(err) {
+		t.Fatalf("expected rate limited error, got %v", err)
+	}
+	if delay := limiter.family("/v1/payments/payouts/B-1").reserve(clock.Now()); delay != time.Second {
+		t.Errorf("expected payouts calls to wait for Retry-After, got a delay of %s", delay)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := c.GetPayout(ctx, "B-1"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected payouts calls to wait for Retry-After, got %v", err)
+	}
+	if _, err := c.GetOrder(context.Background(), "O-1"); err != nil {
+		t.Errorf("expected other families not to wait, got %v", err)
+	}
+
+	clock.Advance(time.Second)
+	if _, err := c.GetPayout(context.Background(), "B-1"); err != nil {
+		t.Errorf("expected payouts calls once Retry-After elapsed, got %v", err)
+	}
+}
@@ -0,0 +1,327 @@
+package paypal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrOverRefund is matched by the *OverRefundError returned for refunds exceeding the refundable balance of a capture
+var ErrOverRefund = errors.New("paypal: refund exceeds refundable balance")
+
+// ErrNothingToRefund is returned by RefundPartial and RefundRemaining without calling PayPal for a fully refunded capture
+var ErrNothingToRefund = errors.New("paypal: nothing left to refund")
+
+// OverRefundError is returned by RefundPartial and RefundRemaining without calling PayPal
+// when the refund exceeds the refundable balance of the capture
+type OverRefundError struct {
+	CaptureID string
+	Requested Money
+	Remaining Money
+}
+
+// Error implements error
+func (e *OverRefundError) Error() string {
+	return fmt.Sprintf("paypal: refund of %s %s exceeds the refundable balance %s %s of capture %s",
+		e.Requested.Value, e.Requested.Currency, e.Remaining.Value, e.Remaining.Currency, e.CaptureID)
+}
+
+// Is makes an OverRefundError match ErrOverRefund
+func (e *OverRefundError) Is(target error) bool {
+	return target == ErrOverRefund
+}
+
+// RefundableBalance is how much of a capture was refunded and can still be refunded
+type RefundableBalance struct {
+	CaptureID string
+	// Captured is the gross amount of the capture
+	Captured Money
+	// Refunded is the gross amount of the completed and pending refunds
+	Refunded Money
+	// Remaining is the amount which can still be refunded to the payer
+	Remaining Money
+	// PlatformFees is the total of the platform fees of the capture, zero if it has none
+	PlatformFees Money
+	// NetRemaining is the net amount the seller keeps if nothing more is refunded, nil if PayPal did not return the net amounts
+	NetRemaining *Money
+}
+
+// CalculateRefundableBalance returns the refundable balance of a capture, see CapturedDetail.
+// Refunds of other captures are ignored, so all refunds of the order can be passed.
+// Refunds are matched to the capture by their up link, refunds without one are skipped.
+func CalculateRefundableBalance(capture *CaptureDetailsResponse, refunds []RefundResponse) (*RefundableBalance, error) {
+	gross := capture.Amount
+	breakdown := capture.SellerReceivableBreakdown
+	if breakdown != nil && breakdown.GrossAmount != nil {
+		gross = breakdown.GrossAmount
+	}
+	if gross == nil {
+		return nil, fmt.Errorf("paypal: capture %s has no amount", capture.ID)
+	}
+	currency := gross.Currency
+
+	captured, err := parseMinorUnits(gross, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	var platformFees int64
+	if breakdown != nil {
+		for _, fee := range breakdown.PlatformFees {
+			units, err := parseMinorUnits(fee.Amount, currency)
+			if err != nil {
+				return nil, err
+			}
+			platformFees += units
+		}
+	}
+
+	var (
+		refunded, refundedNet int64
+		totalRefunded         int64
+		netKnown              = breakdown != nil && breakdown.NetAmount != nil
+	)
+	for _, refund := range refunds {
+		up := findLink(refund.Links, "up")
+		if up == "" || paymentResourceKey(up) != "captures/"+capture.ID {
+			continue
+		}
+		if refund.Status != "COMPLETED" && refund.Status != "PENDING" {
+			continue
+		}
+
+		units, err := parseMinorUnits(purchaseUnitMoney(refund.Amount), currency)
+		if err != nil {
+			return nil, err
+		}
+		refunded += units
+
+		payable := refund.SellerPayableBreakdown
+		if payable == nil {
+			netKnown = false
+			continue
+		}
+
+		// The running total of the capture also covers refunds missing from the list
+		total, err := parseMinorUnits(payable.TotalRefundedAmount, currency)
+		if err != nil {
+			return nil, err
+		}
+		totalRefunded = max(totalRefunded, total)
+
+		if payable.NetAmount == nil {
+			netKnown = false
+			continue
+		}
+		net, err := parseMinorUnits(payable.NetAmount, currency)
+		if err != nil {
+			return nil, err
+		}
+		refundedNet += net
+	}
+	refunded = max(refunded, totalRefunded)
+
+	remaining := max(captured-refunded, 0)
+	if capture.Status == "REFUNDED" {
+		remaining = 0
+	}
+
+	balance := &RefundableBalance{
+		CaptureID:    capture.ID,
+		Captured:     formatMinorUnits(captured, currency),
+		Refunded:     formatMinorUnits(refunded, currency),
+		Remaining:    formatMinorUnits(remaining, currency),
+		PlatformFees: formatMinorUnits(platformFees, currency),
+	}
+	if netKnown {
+		net, err := parseMinorUnits(breakdown.NetAmount, currency)
+		if err != nil {
+			return nil, err
+		}
+		netRemaining := formatMinorUnits(net-refundedNet, currency)
+		balance.NetRemaining = &netRemaining
+	}
+
+	return balance, nil
+}
+
+// GetRefundableBalance returns the refundable balance of a capture,
+// its refunds are found in the order the capture belongs to by following the up links
+func (c *Client) GetRefundableBalance(ctx context.Context, captureID string, opts ...CallOption) (*RefundableBalance, error) {
+	ctx = withCallOptions(ctx, opts)
+
+	capture, err := c.CapturedDetail(ctx, captureID)
+	if err != nil {
+		return nil, err
+	}
+
+	refunds, err := c.orderRefunds(ctx, capture.Links)
+	if err != nil {
+		return nil, err
+	}
+
+	return CalculateRefundableBalance(capture, refunds)
+}
+
+// RefundPartial refunds an amount of a capture, refusing with an *OverRefundError to refund more than its refundable balance,
+// and with ErrNothingToRefund once the capture is fully refunded.
+//
+// The PayPal-Request-Id is derived from the capture ID, the amount and the reason,
+// so sending the same refund again does not refund the payer twice. As a consequence,
+// a second refund with the same amount and reason is NOT sent: PayPal returns the first one.
+// Give each refund its own ContextWithIdempotencyKey (e.g. the ID of the return) or WithRequestID
+// to refund the same amount several times.
+func (c *Client) RefundPartial(ctx context.Context, captureID string, amount Money, reason string, opts ...CallOption) (*RefundResponse, error) {
+	ctx = withCallOptions(ctx, opts)
+
+	balance, err := c.GetRefundableBalance(ctx, captureID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.refundBalance(ctx, balance, amount, reason)
+}
+
+// RefundRemaining refunds the refundable balance of a capture, see RefundPartial for the PayPal-Request-Id.
+// A fully refunded capture returns ErrNothingToRefund.
+func (c *Client) RefundRemaining(ctx context.Context, captureID string, reason string, opts ...CallOption) (*RefundResponse, error) {
+	ctx = withCallOptions(ctx, opts)
+
+	balance, err := c.GetRefundableBalance(ctx, captureID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.refundBalance(ctx, balance, balance.Remaining, reason)
+}
+
+func (c *Client) refundBalance(ctx context.Context, balance *RefundableBalance, amount Money, reason string) (*RefundResponse, error) {
+	currency := balance.Remaining.Currency
+	if !strings.EqualFold(amount.Currency, currency) {
+		return nil, fmt.Errorf("paypal: refund currency %s does not match capture currency %s", amount.Currency, currency)
+	}
+
+	requested, err := parseMinorUnits(&amount, currency)
+	if err != nil {
+		return nil, err
+	}
+	remaining, err := parseMinorUnits(&balance.Remaining, currency)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case remaining == 0:
+		return nil, fmt.Errorf("%w: capture %s is fully refunded", ErrNothingToRefund, balance.CaptureID)
+	case requested <= 0:
+		return nil, fmt.Errorf("paypal: refund amount must be positive, got %s %s", amount.Value, amount.Currency)
+	case requested > remaining:
+		return nil, &OverRefundError{
+			CaptureID: balance.CaptureID,
+			Requested: formatMinorUnits(requested, currency),
+			Remaining: balance.Remaining,
+		}
+	}
+
+	amount = formatMinorUnits(requested, currency)
+	// The PayPal-Request-Id is derived from the idempotency key of the caller if any, see setRequestID
+	var requestID string
+	if key, _ := ctx.Value(idempotencyKey{}).(string); key == "" {
+		path := "/v2/payments/captures/" + balance.CaptureID + "/refund"
+		requestID = deriveRequestID(balance.CaptureID+"\x00"+amount.Value+" "+amount.Currency+"\x00"+reason, "POST", path)
+	}
+
+	return c.RefundCaptureWithPaypalRequestId(ctx, balance.CaptureID, RefundCaptureRequest{
+		Amount:      &amount,
+		NoteToPayer: reason,
+	}, requestID)
+}
+
+// maxUpLinks is the number of up links followed to find the order of a capture
+const maxUpLinks = 3
+
+// orderRefunds returns the refunds of the order found by following the up links,
+// from a capture to its authorization if any and then to its order
+func (c *Client) orderRefunds(ctx context.Context, links []Link) ([]RefundResponse, error) {
+	up := findLink(links, "up")
+	for step := 0; up != ""; step++ {
+		if step == maxUpLinks {
+			return nil, fmt.Errorf("paypal: no order found within %d up links", maxUpLinks)
+		}
+
+		if strings.Contains(up, "/checkout/orders/") {
+			order := &Order{}
+			if err := c.getLink(ctx, up, order); err != nil {
+				return nil, err
+			}
+
+			var refunds []RefundResponse
+			for _, unit := range order.PurchaseUnits {
+				if unit.Payments != nil {
+					refunds = append(refunds, unit.Payments.Refunds...)
+				}
+			}
+			return refunds, nil
+		}
+
+		auth := &Authorization{}
+		if err := c.getLink(ctx, up, auth); err != nil {
+			return nil, err
+		}
+		up = findLink(auth.Links, "up")
+	}
+
+	return nil, nil
+}
+
+// currencyPrecision returns the number of decimals PayPal accepts for a currency
+// https://developer.paypal.com/reference/currency-codes/
+func currencyPrecision(currency string) int {
+	switch strings.ToUpper(currency) {
+	case "HUF", "JPY", "TWD":
+		return 0
+	}
+	return 2
+}
+
+// parseMinorUnits returns the amount in the smallest unit of the currency, e.g. cents
+func parseMinorUnits(money *Money, currency string) (int64, error) {
+	if money == nil {
+		return 0, nil
+	}
+	if money.Currency != "" && !strings.EqualFold(money.Currency, currency) {
+		return 0, fmt.Errorf("paypal: amount in %s, expected %s", money.Currency, currency)
+	}
+
+	precision := currencyPrecision(currency)
+	whole, fraction, _ := strings.Cut(money.Value, ".")
+	fraction = strings.TrimRight(fraction, "0")
+	if len(fraction) > precision || whole == "" || strings.HasPrefix(whole, "-") || strings.HasPrefix(whole, "+") {
+		return 0, fmt.Errorf("paypal: invalid %s amount %q", currency, money.Value)
+	}
+
+	units, err := strconv.ParseInt(whole+fraction+strings.Repeat("0", precision-len(fraction)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("paypal: invalid %s amount %q", currency, money.Value)
+	}
+	return units, nil
+}
+
+// formatMinorUnits is the inverse of parseMinorUnits
+func formatMinorUnits(units int64, currency string) Money {
+	precision := currencyPrecision(currency)
+	value := strconv.FormatInt(units, 10)
+	if precision == 0 {
+		return Money{Currency: currency, Value: value}
+	}
+
+	sign := ""
+	if units < 0 {
+		sign, value = "-", value[1:]
+	}
+	if len(value) <= precision {
+		value = strings.Repeat("0", precision-len(value)+1) + value
+	}
+	return Money{Currency: currency, Value: sign + value[:len(value)-precision] + "." + value[len(value)-precision:]}
+}
@@ -0,0 +1,164 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestCalculateRefundableBalance(t *testing.T) {
+	capture := &CaptureDetailsResponse{
+		ID:     "C-1",
+		Status: "PARTIALLY_REFUNDED",
+		Amount: &Money{Currency: "USD", Value: "100.00"},
+		SellerReceivableBreakdown: &SellerReceivableBreakdown{
+			GrossAmount:  &Money{Currency: "USD", Value: "100.00"},
+			PaypalFee:    &Money{Currency: "USD", Value: "3.20"},
+			NetAmount:    &Money{Currency: "USD", Value: "91.80"},
+			PlatformFees: []PlatformFee{{Amount: &Money{Currency: "USD", Value: "5"}}},
+		},
+	}
+	up := func(captureID string) []Link {
+		return []Link{{Rel: "up", Href: "https://api.paypal.com/v2/payments/captures/" + captureID}}
+	}
+	refunds := []RefundResponse{
+		{ID: "R-1", Status: "COMPLETED", Amount: &PurchaseUnitAmount{Currency: "USD", Value: "30.5"}, Links: up("C-1"),
+			SellerPayableBreakdown: &SellerPayableBreakdown{NetAmount: &Money{Currency: "USD", Value: "29.50"}}},
+		{ID: "R-2", Status: "FAILED", Amount: &PurchaseUnitAmount{Currency: "USD", Value: "10.00"}, Links: up("C-1")},
+		{ID: "R-3", Status: "COMPLETED", Amount: &PurchaseUnitAmount{Currency: "USD", Value: "10.00"}, Links: up("C-2")},
+		{ID: "R-4", Status: "COMPLETED", Amount: &PurchaseUnitAmount{Currency: "USD", Value: "5.00"}},
+	}
+
+	balance, err := CalculateRefundableBalance(capture, refunds)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if balance.Refunded.Value != "30.50" || balance.Remaining.Value != "69.50" || balance.PlatformFees.Value != "5.00" {
+		t.Errorf("unexpected balance %+v", balance)
+	}
+	if balance.NetRemaining == nil || balance.NetRemaining.Value != "62.30" {
+		t.Errorf("unexpected net remaining %+v", balance.NetRemaining)
+	}
+
+	capture.SellerReceivableBreakdown = nil
+	capture.Amount = &Money{Currency: "JPY", Value: "1000"}
+	if _, err := CalculateRefundableBalance(capture, nil); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	capture.Amount.Value = "1000.50"
+	if _, err := CalculateRefundableBalance(capture, nil); err == nil {
+		t.Errorf("expected JPY amounts with decimals to be refused")
+	}
+}
+
+func TestRefundPartial(t *testing.T) {
+	var requestIDs []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		base := "http://" + r.Host
+		switch r.URL.Path {
+		case "/v2/payments/captures/C-1":
+			fmt.Fprintf(w, `{"id":"C-1","status":"PARTIALLY_REFUNDED","amount":{"currency_code":"EUR","value":"50.00"},
+				"links":[{"rel":"up","href":"%s/v2/checkout/orders/O-1"}]}`, base)
+		case "/v2/checkout/orders/O-1":
+			fmt.Fprintf(w, `{"id":"O-1","purchase_units":[{"reference_id":"default","payments":{"refunds":[
+				{"id":"R-1","status":"COMPLETED","amount":{"currency_code":"EUR","value":"20.00"},
+				 "links":[{"rel":"up","href":"%s/v2/payments/captures/C-1"}]}]}}]}`, base)
+		case "/v2/payments/captures/C-1/refund":
+			requestIDs = append(requestIDs, r.Header.Get("PayPal-Request-Id"))
+			var body RefundCaptureRequest
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Amount.Value != "10.00" || body.NoteToPayer != "damaged" {
+				t.Errorf("unexpected refund %+v (%v)", body, err)
+			}
+			_, _ = w.Write([]byte(`{"id":"R-2","status":"COMPLETED"}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	})
+
+	_, err := c.RefundPartial(context.Background(), "C-1", Money{Currency: "EUR", Value: "30.01"}, "damaged")
+	var overRefund *OverRefundError
+	if !errors.Is(err, ErrOverRefund) || !errors.As(err, &overRefund) || overRefund.Remaining.Value != "30.00" {
+		t.Fatalf("expected over refund error, got %v", err)
+	}
+	for _, value := range []string{"0.00", "-5.00"} {
+		if _, err := c.RefundPartial(context.Background(), "C-1", Money{Currency: "EUR", Value: value}, "damaged"); err == nil || errors.Is(err, ErrOverRefund) {
+			t.Errorf("expected a validation error for %s, got %v", value, err)
+		}
+	}
+	if len(requestIDs) != 0 {
+		t.Fatalf("expected the invalid refunds not to be sent")
+	}
+
+	refund := func(ctx context.Context, opts ...CallOption) {
+		if _, err := c.RefundPartial(ctx, "C-1", Money{Currency: "EUR", Value: "10"}, "damaged", opts...); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+
+	// Sending the same refund again reuses its request ID
+	refund(context.Background())
+	refund(context.Background())
+	if len(requestIDs) != 2 || requestIDs[0] == "" || requestIDs[0] != requestIDs[1] {
+		t.Fatalf("expected the same refund to reuse its request ID, got %v", requestIDs)
+	}
+
+	// Two refunds of the same amount and reason are told apart by the idempotency key of the caller
+	refund(ContextWithIdempotencyKey(context.Background(), "return-1"))
+	refund(ContextWithIdempotencyKey(context.Background(), "return-2"))
+	refund(context.Background(), WithRequestID("request-1"))
+	if len(requestIDs) != 5 || requestIDs[2] == requestIDs[0] || requestIDs[2] == requestIDs[3] || requestIDs[4] != "request-1" {
+		t.Errorf("expected distinct request IDs for distinct refunds, got %v", requestIDs)
+	}
+}
+
+func TestGetRefundableBalance_UpLinks(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		base := "http://" + r.Host
+		switch r.URL.Path {
+		case "/v2/payments/captures/C-1":
+			fmt.Fprintf(w, `{"id":"C-1","status":"COMPLETED","amount":{"currency_code":"EUR","value":"50.00"},
+				"links":[{"rel":"up","href":"%s/v2/payments/authorizations/A-1"}]}`, base)
+		case "/v2/payments/authorizations/A-1":
+			// Authorizations pointing to each other never reach an order
+			fmt.Fprintf(w, `{"id":"A-1","links":[{"rel":"up","href":"%s/v2/payments/authorizations/A-1"}]}`, base)
+		case "/v2/payments/captures/C-2":
+			fmt.Fprintf(w, `{"id":"C-2","status":"COMPLETED","amount":{"currency_code":"EUR","value":"50.00"},
+				"links":[{"rel":"up","href":"%s/v2/checkout/orders/O-2"}]}`, base)
+		case "/v2/checkout/orders/O-2":
+			_, _ = w.Write([]byte(`{"id":"O-2","purchase_units":[{"payments":{"refunds":[
+				{"id":"R-1","status":"COMPLETED","amount":{"currency_code":"EUR","value":"20.00"}}]}}]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	})
+
+	if _, err := c.GetRefundableBalance(context.Background(), "C-1"); err == nil {
+		t.Error("expected an error once the up links are exhausted")
+	}
+	balance, err := c.GetRefundableBalance(context.Background(), "C-2")
+	if err != nil {
+		t.Fatalf("expected refunds without up link to be skipped, got %v", err)
+	}
+	if balance.Remaining.Value != "50.00" {
+		t.Errorf("unexpected balance %+v", balance)
+	}
+}
+
+func TestRefundRemaining_FullyRefunded(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/payments/captures/C-1":
+			_, _ = w.Write([]byte(`{"id":"C-1","status":"REFUNDED","amount":{"currency_code":"EUR","value":"50.00"}}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	})
+
+	_, err := c.RefundRemaining(context.Background(), "C-1", "cancelled")
+	if !errors.Is(err, ErrNothingToRefund) || errors.Is(err, ErrOverRefund) {
+		t.Errorf("expected nothing to refund, got %v", err)
+	}
+}
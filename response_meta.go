@@ -0,0 +1,85 @@
+package paypal
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+)
+
+// ResponseMeta describes the HTTP response to an API call, it's filled by the client
+// for calls made with a context returned by ContextWithResponseMeta
+type ResponseMeta struct {
+	// StatusCode of the last response received
+	StatusCode int
+	// Header of the last response received, e.g. Paypal-Debug-Id or rate limit headers
+	Header http.Header
+	// DebugID identifies the call for PayPal support
+	DebugID string
+	// Duration of the call including retries
+	Duration time.Duration
+	// Body is the raw response body
+	Body []byte
+}
+
+type responseMetaKey struct{}
+
+// ContextWithResponseMeta returns a context making API calls record their response metadata in meta.
+// Both successful and failed calls fill meta, it's left empty if no response was received.
+//
+//	var meta paypal.ResponseMeta
+//	capture, err := c.CaptureOrder(paypal.ContextWithResponseMeta(ctx, &meta), orderID, paypal.CaptureOrderRequest{})
+//	log.Println(meta.DebugID)
+func ContextWithResponseMeta(ctx context.Context, meta *ResponseMeta) context.Context {
+	return context.WithValue(ctx, responseMetaKey{}, meta)
+}
+
+// responseMetaFromContext returns the ResponseMeta to fill for a call, or nil
+func responseMetaFromContext(ctx context.Context) *ResponseMeta {
+	meta, _ := ctx.Value(responseMetaKey{}).(*ResponseMeta)
+	return meta
+}
+
+// withoutResponseMeta prevents requests made on behalf of an API call, like token requests,
+// from overwriting the response metadata of the call
+func withoutResponseMeta(ctx context.Context) context.Context {
+	if responseMetaFromContext(ctx) == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, responseMetaKey{}, (*ResponseMeta)(nil))
+}
+
+// recordResponseMeta fills meta from the response or the *ErrorResponse returned by roundTrip.
+// The body is read and replaced so it can still be decoded.
+func recordResponseMeta(meta *ResponseMeta, resp *http.Response, err error, duration time.Duration) error {
+	*meta = ResponseMeta{Duration: duration}
+
+	var errResp *ErrorResponse
+	if errors.As(err, &errResp) && errResp.Response != nil {
+		resp = errResp.Response
+		meta.DebugID = errResp.DebugID
+	}
+	if resp == nil {
+		return nil
+	}
+
+	meta.StatusCode = resp.StatusCode
+	meta.Header = resp.Header
+	if debugID := resp.Header.Get("Paypal-Debug-Id"); debugID != "" {
+		meta.DebugID = debugID
+	}
+	if resp.Body == nil {
+		return nil
+	}
+
+	data, readErr := io.ReadAll(resp.Body)
+	if closeErr := resp.Body.Close(); readErr == nil {
+		readErr = closeErr
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	meta.Body = data
+
+	return readErr
+}
@@ -0,0 +1,59 @@
+package paypal
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+)
+
+func TestContextWithResponseMeta(t *testing.T) {
+	var tokenRequests atomic.Int32
+	server := newTokenTestServer(t, &tokenRequests, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Paypal-Debug-Id", "debug-1")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"O-1","status":"COMPLETED"}`))
+	})
+
+	c, err := NewClient("clientID", "secret", server.URL)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	var meta ResponseMeta
+	order, err := c.GetOrder(ContextWithResponseMeta(context.Background(), &meta), "O-1")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if order.ID != "O-1" {
+		t.Errorf("expected the response to be decoded, got %+v", order)
+	}
+	if tokenRequests.Load() != 1 {
+		t.Errorf("expected a token request, got %d", tokenRequests.Load())
+	}
+	if meta.StatusCode != http.StatusCreated || meta.DebugID != "debug-1" {
+		t.Errorf("expected metadata of the order response, got %+v", meta)
+	}
+	if string(meta.Body) != `{"id":"O-1","status":"COMPLETED"}` {
+		t.Errorf("unexpected body %q", meta.Body)
+	}
+	if meta.Duration <= 0 {
+		t.Errorf("expected a duration")
+	}
+}
+
+func TestContextWithResponseMeta_Error(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"name":"RESOURCE_NOT_FOUND","debug_id":"debug-2"}`))
+	})
+
+	var meta ResponseMeta
+	_, err := c.GetOrder(ContextWithResponseMeta(context.Background(), &meta), "O-1")
+	if !IsNotFound(err) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+	if meta.StatusCode != http.StatusNotFound || meta.DebugID != "debug-2" {
+		t.Errorf("expected metadata of the error response, got %+v", meta)
+	}
+}
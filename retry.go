@@ -0,0 +1,203 @@
+package paypal
+
+import (
+	"errors"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy describes how Send retries requests which failed with a transient error:
+// a network error, 429 Too Many Requests or a 5xx response.
+// Only idempotent requests (GET, HEAD, OPTIONS, PUT, DELETE) and requests carrying
+// a PayPal-Request-Id header are retried, so a retry can never create a second payment.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts including the first one.
+	// Values lower than 2 disable retries.
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry.
+	BaseDelay time.Duration
+	// MaxDelay caps a single delay. A Retry-After longer than MaxDelay stops retrying.
+	MaxDelay time.Duration
+	// Multiplier is the growth factor of the delay between attempts, 2 if not set.
+	Multiplier float64
+	// Jitter is the fraction (0..1) of every delay which is randomized.
+	Jitter float64
+	// IgnoreRetryAfter disables waiting for the Retry-After header returned by PayPal.
+	IgnoreRetryAfter bool
+}
+
+// DefaultRetryPolicy returns the policy recommended for PayPal APIs:
+// 4 attempts with exponential backoff starting at 500ms and capped at 10s
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 4,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    10 * time.Second,
+		Multiplier:  2,
+		Jitter:      0.5,
+	}
+}
+
+// SetRetryPolicy enables automatic retries of transient failures in Send
+func (c *Client) SetRetryPolicy(policy RetryPolicy) {
+	c.retryPolicy = &policy
+}
+
+// backoff returns the delay before the given retry (1 for the first retry).
+// It returns false if the Retry-After requested by PayPal exceeds MaxDelay.
+func (p *RetryPolicy) backoff(retry int, resp *http.Response) (time.Duration, bool) {
+	multiplier := p.Multiplier
+	if multiplier <= 0 {
+		multiplier = 2
+	}
+
+	delay := time.Duration(float64(p.BaseDelay) * math.Pow(multiplier, float64(retry-1)))
+	if p.MaxDelay > 0 && (delay > p.MaxDelay || delay < 0) {
+		delay = p.MaxDelay
+	}
+	if p.Jitter > 0 && delay > 0 {
+		jitter := math.Min(p.Jitter, 1)
+		delay = delay - time.Duration(jitter*float64(delay)) + time.Duration(rand.Int64N(int64(jitter*float64(delay))+1))
+	}
+
+	if !p.IgnoreRetryAfter && resp != nil {
+		if after, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			if p.MaxDelay > 0 && after > p.MaxDelay {
+				return 0, false
+			}
+			if after > delay {
+				delay = after
+			}
+		}
+	}
+
+	return delay, true
+}
+
+// parseRetryAfter parses both forms of the Retry-After header: delay in seconds and HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+
+	return 0, false
+}
+
+// isIdempotentRequest reports whether the request can be safely sent more than once
+func isIdempotentRequest(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	// Requesting an access token does not change any state, unlike exchanging a single-use authorization code
+	if req.URL.Path == "/v1/oauth2/token" {
+		switch tokenGrantType(req) {
+		case "client_credentials", "refresh_token":
+			return true
+		}
+		return false
+	}
+
+	return req.Header.Get("PayPal-Request-Id") != ""
+}
+
+// tokenGrantType returns the grant_type of a token request, empty if its body cannot be read again
+func tokenGrantType(req *http.Request) string {
+	if req.GetBody == nil {
+		return ""
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+
+	b, err := io.ReadAll(body)
+	if err != nil {
+		return ""
+	}
+	form, err := url.ParseQuery(string(b))
+	if err != nil {
+		return ""
+	}
+	return form.Get("grant_type")
+}
+
+// shouldRetry reports whether a failed attempt can be sent again
+func shouldRetry(req *http.Request, err error) bool {
+	return isIdempotentRequest(req) && IsTransient(err)
+}
+
+// sendWithRetry sends the request and retries it according to the client RetryPolicy
+func (c *Client) sendWithRetry(req *http.Request) (*http.Response, error) {
+	return retry(c.retryPolicy, req, c.sendOnce)
+}
+
+// RetryMiddleware retries the rest of the middleware chain according to the policy.
+// Unlike SetRetryPolicy, which retries the bare HTTP call, every attempt goes through
+// the middlewares registered after this one.
+func RetryMiddleware(policy RetryPolicy) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			return retry(&policy, req, next)
+		}
+	}
+}
+
+// retry sends the request with next, and sends it again after transient failures according to the policy
+func retry(policy *RetryPolicy, req *http.Request, next RoundTripFunc) (*http.Response, error) {
+	if policy == nil || policy.MaxAttempts < 2 || (req.Body != nil && req.Body != http.NoBody && req.GetBody == nil) {
+		return next(req)
+	}
+
+	for attempt := 1; ; attempt++ {
+		attemptReq := req
+		if attempt > 1 {
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				req.Body = body
+			}
+			attemptReq = req.WithContext(withAttempt(req.Context(), attempt))
+		}
+
+		resp, err := next(attemptReq)
+		if err == nil || attempt >= policy.MaxAttempts || !shouldRetry(req, err) {
+			return resp, err
+		}
+
+		var errResp *ErrorResponse
+		if errors.As(err, &errResp) {
+			resp = errResp.Response
+		}
+		delay, ok := policy.backoff(attempt, resp)
+		if !ok {
+			return resp, err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
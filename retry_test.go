@@ -0,0 +1,107 @@
+package paypal
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newRetryTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	c := newTestClient(t, handler)
+	c.SetRetryPolicy(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 50 * time.Millisecond})
+
+	return c
+}
+
+func TestSend_RetriesIdempotentRequest(t *testing.T) {
+	var attempts atomic.Int32
+	c := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"O-1"}`))
+	})
+
+	order, err := c.GetOrder(context.Background(), "O-1")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if order.ID != "O-1" || attempts.Load() != 3 {
+		t.Errorf("got order %q after %d attempts, wanted O-1 after 3", order.ID, attempts.Load())
+	}
+}
+
+func TestSend_RewindsBodyOnRetry(t *testing.T) {
+	var attempts atomic.Int32
+	c := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"amount":{"currency_code":"USD","value":"1.00"}}` {
+			t.Errorf("unexpected body on attempt %d: %s", attempts.Load()+1, body)
+		}
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"R-1"}`))
+	})
+
+	refund, err := c.RefundCaptureWithPaypalRequestId(context.Background(), "C-1", RefundCaptureRequest{Amount: &Money{Currency: "USD", Value: "1.00"}}, "request-1")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if refund.ID != "R-1" || attempts.Load() != 2 {
+		t.Errorf("got refund %q after %d attempts, wanted R-1 after 2", refund.ID, attempts.Load())
+	}
+}
+
+func TestSend_DoesNotRetryPostWithoutRequestID(t *testing.T) {
+	var attempts atomic.Int32
+	c := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	_, err := c.CreatePayout(context.Background(), Payout{})
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) || errResp.Response.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 ErrorResponse, got %v", err)
+	}
+	if attempts.Load() != 1 {
+		t.Errorf("got %d attempts, wanted 1", attempts.Load())
+	}
+}
+
+func TestSend_StopsWhenRetryAfterExceedsMaxDelay(t *testing.T) {
+	var attempts atomic.Int32
+	c := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	if _, err := c.GetPayout(context.Background(), "BATCH-1"); err == nil {
+		t.Fatal("expected an error")
+	}
+	if attempts.Load() != 1 {
+		t.Errorf("got %d attempts, wanted 1", attempts.Load())
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if d, ok := parseRetryAfter("3"); !ok || d != 3*time.Second {
+		t.Errorf("got %v %v, wanted 3s", d, ok)
+	}
+	if _, ok := parseRetryAfter("soon"); ok {
+		t.Error("expected invalid value to be rejected")
+	}
+	date := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+	if d, ok := parseRetryAfter(date); !ok || d <= 0 || d > time.Minute {
+		t.Errorf("got %v %v for HTTP date", d, ok)
+	}
+}
@@ -7,11 +7,34 @@ import (
 
 // GetRefund by ID
 // Use it to look up details of a specific refund on direct and captured payments.
-// Endpoint: GET /v2/payments/refund/ID
-func (c *Client) GetRefund(ctx context.Context, refundID string) (*Refund, error) {
+// Endpoint: GET /v2/payments/refunds/ID
+//
+// Deprecated: Refund does not match the v2 refund, use GetRefundDetails.
+func (c *Client) GetRefund(ctx context.Context, refundID string, opts ...CallOption) (*Refund, error) {
+	ctx = withCallOptions(ctx, opts)
+
 	refund := &Refund{}
 
-	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s%s", c.APIBase, "/v2/payments/refund/"+refundID), nil)
+	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s%s", c.APIBase, "/v2/payments/refunds/"+refundID), nil)
+	if err != nil {
+		return refund, err
+	}
+
+	if err = c.SendWithAuth(req, refund); err != nil {
+		return refund, err
+	}
+
+	return refund, nil
+}
+
+// GetRefundDetails by ID - https://developer.paypal.com/docs/api/payments/v2/#refunds_get
+// Endpoint: GET /v2/payments/refunds/ID
+func (c *Client) GetRefundDetails(ctx context.Context, refundID string, opts ...CallOption) (*RefundResponse, error) {
+	ctx = withCallOptions(ctx, opts)
+
+	refund := &RefundResponse{}
+
+	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s%s", c.APIBase, "/v2/payments/refunds/"+refundID), nil)
 	if err != nil {
 		return refund, err
 	}
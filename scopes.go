@@ -0,0 +1,120 @@
+package paypal
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Scopes required by APIs which are not granted to every app
+const (
+	ScopeDisputesReadSeller   string = "https://uri.paypal.com/services/disputes/read-seller"
+	ScopeDisputesReadBuyer    string = "https://uri.paypal.com/services/disputes/read-buyer"
+	ScopeDisputesUpdateSeller string = "https://uri.paypal.com/services/disputes/update-seller"
+	ScopeTransactionSearch    string = "https://uri.paypal.com/services/reporting/search/read"
+	ScopePayouts              string = "https://uri.paypal.com/services/payments/payouts"
+)
+
+// ScopeSet is a set of OAuth scopes
+type ScopeSet map[string]struct{}
+
+// Has reports whether the scope is in the set
+func (s ScopeSet) Has(scope string) bool {
+	_, ok := s[scope]
+	return ok
+}
+
+// Scopes returns the scopes granted to the app, empty if PayPal did not return them
+func (t *TokenResponse) Scopes() ScopeSet {
+	scopes := make(ScopeSet)
+	for _, scope := range strings.Fields(t.Scope) {
+		scopes[scope] = struct{}{}
+	}
+	return scopes
+}
+
+// HasScope reports whether the current access token of the client was granted the scope.
+// It returns false if the client has no token yet or the token has no scope information.
+func (c *Client) HasScope(scope string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.Token != nil && c.Token.Scopes().Has(scope)
+}
+
+// SetScopePreflight makes SendWithAuth check the access token was granted the scope required
+// by Disputes, Transaction Search and Payouts calls before sending them.
+// Calls missing a scope fail fast with a *ScopeError instead of a 403 NOT_AUTHORIZED from PayPal.
+// Tokens without scope information, e.g. set with SetAccessToken, are not checked.
+func (c *Client) SetScopePreflight(enabled bool) {
+	c.scopePreflight = enabled
+}
+
+// ScopeError is returned when the access token was not granted the scope required by a call, see SetScopePreflight.
+// It matches ErrNotAuthorized and ErrorCategoryAuth with errors.Is.
+type ScopeError struct {
+	Method string
+	Path   string
+	// Scopes lists the scopes the call accepts, one of them is enough
+	Scopes []string
+}
+
+// Error implements error
+func (e *ScopeError) Error() string {
+	return "paypal: " + e.Method + " " + e.Path + " requires the scope " + strings.Join(e.Scopes, " or ") +
+		" which was not granted to the app"
+}
+
+// Is makes a ScopeError match ErrNotAuthorized and its category
+func (e *ScopeError) Is(target error) bool {
+	return target == ErrNotAuthorized || target == ErrorCategoryAuth
+}
+
+// requiredScope lists the scopes accepted by the API calls matching the path prefix and method (all methods if empty)
+type requiredScope struct {
+	prefix string
+	method string
+	scopes []string
+}
+
+var requiredScopes = []requiredScope{
+	{prefix: "/v1/customer/disputes", method: http.MethodGet, scopes: []string{ScopeDisputesReadSeller, ScopeDisputesReadBuyer}},
+	{prefix: "/v1/customer/disputes", scopes: []string{ScopeDisputesUpdateSeller}},
+	{prefix: "/v1/reporting/transactions", scopes: []string{ScopeTransactionSearch}},
+	{prefix: "/v1/payments/payouts", scopes: []string{ScopePayouts}},
+}
+
+// checkScopes returns a *ScopeError if the token is known not to be granted a scope required by the request
+func checkScopes(req *http.Request, token *TokenResponse) error {
+	if token == nil || token.Scope == "" {
+		return nil
+	}
+
+	for _, required := range requiredScopes {
+		if !strings.HasPrefix(req.URL.Path, required.prefix) || (required.method != "" && required.method != req.Method) {
+			continue
+		}
+
+		granted := token.Scopes()
+		for _, scope := range required.scopes {
+			if granted.Has(scope) {
+				return nil
+			}
+		}
+		return &ScopeError{Method: req.Method, Path: req.URL.Path, Scopes: required.scopes}
+	}
+
+	return nil
+}
+
+// preflightScopes checks the scopes of the client token if SetScopePreflight is enabled
+func (c *Client) preflightScopes(req *http.Request) error {
+	if !c.scopePreflight {
+		return nil
+	}
+
+	c.mu.Lock()
+	token := c.Token
+	c.mu.Unlock()
+
+	return checkScopes(req, token)
+}
@@ -0,0 +1,58 @@
+package paypal
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestSetScopePreflight(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/oauth2/token" {
+			_, _ = w.Write([]byte(`{"access_token":"token","app_id":"APP-1","expires_in":3600,
+				"scope":"https://uri.paypal.com/services/payments/payouts https://uri.paypal.com/services/disputes/read-seller"}`))
+			return
+		}
+		calls.Add(1)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	c, err := NewClient("clientID", "secret", server.URL)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	c.SetScopePreflight(true)
+
+	if _, err := c.GetPayout(context.Background(), "B-1"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !c.HasScope(ScopePayouts) || c.HasScope(ScopeTransactionSearch) || c.Token.AppID != "APP-1" {
+		t.Errorf("unexpected token %+v", c.Token)
+	}
+
+	if _, err := c.ListDisputes(context.Background(), &ListDisputesRequest{}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	_, err = c.ListTransactions(context.Background(), &TransactionSearchRequest{})
+	var scopeErr *ScopeError
+	if !errors.As(err, &scopeErr) || scopeErr.Scopes[0] != ScopeTransactionSearch {
+		t.Fatalf("expected a scope error, got %v", err)
+	}
+	if !errors.Is(err, ErrNotAuthorized) || !IsAuth(err) {
+		t.Errorf("expected the scope error to be an authorization error")
+	}
+
+	err = c.DisputeAcceptClaim(context.Background(), "D-1", &DisputeAcceptClaimParams{})
+	if !errors.As(err, &scopeErr) || scopeErr.Scopes[0] != ScopeDisputesUpdateSeller {
+		t.Fatalf("expected a scope error, got %v", err)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("expected calls missing a scope not to be sent, got %d calls", calls.Load())
+	}
+}
@@ -9,7 +9,8 @@ import (
 // PayPal partners and merchants can Set transaction context to send additional data about a customer to PayPal before the customer transaction is processed.
 // PayPal uses this data to complete a pre-transaction risk management evaluation
 // Endpoint: PUT <endpoint>/v1/risk/transaction-contexts/<merchant_id>/<tracking_id>
-func (c *Client) SetTransactionContext(ctx context.Context, merchantID, orderID string, tcPayload interface{}) error {
+func (c *Client) SetTransactionContext(ctx context.Context, merchantID, orderID string, tcPayload interface{}, opts ...CallOption) error {
+	ctx = withCallOptions(ctx, opts)
 
 	if merchantID == "" || orderID == "" {
 		return fmt.Errorf("paypal: merchantID or orderID is empty")
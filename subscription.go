@@ -117,7 +117,9 @@ func (p *Subscription) GetUpdatePatch() []Patch {
 // CreateSubscriptionPlan creates a subscriptionPlan
 // Doc: https://developer.paypal.com/docs/api/subscriptions/v1/#subscriptions_create
 // Endpoint: POST /v1/billing/subscriptions
-func (c *Client) CreateSubscription(ctx context.Context, newSubscription SubscriptionBase) (*SubscriptionDetailResp, error) {
+func (c *Client) CreateSubscription(ctx context.Context, newSubscription SubscriptionBase, opts ...CallOption) (*SubscriptionDetailResp, error) {
+	ctx = withCallOptions(ctx, opts)
+
 	req, err := c.NewRequest(ctx, http.MethodPost, fmt.Sprintf("%s%s", c.APIBase, "/v1/billing/subscriptions"), newSubscription)
 	req.Header.Add("Prefer", "return=representation")
 	response := &SubscriptionDetailResp{}
@@ -131,7 +133,9 @@ func (c *Client) CreateSubscription(ctx context.Context, newSubscription Subscri
 // UpdateSubscriptionPlan. updates a plan
 // Doc: https://developer.paypal.com/docs/api/subscriptions/v1/#subscriptions_patch
 // Endpoint: PATCH /v1/billing/subscriptions/:subscription_id
-func (c *Client) UpdateSubscription(ctx context.Context, updatedSubscription Subscription) error {
+func (c *Client) UpdateSubscription(ctx context.Context, updatedSubscription Subscription, opts ...CallOption) error {
+	ctx = withCallOptions(ctx, opts)
+
 	req, err := c.NewRequest(ctx, http.MethodPatch, fmt.Sprintf("%s%s%s", c.APIBase, "/v1/billing/subscriptions/", updatedSubscription.ID), updatedSubscription.GetUpdatePatch())
 	if err != nil {
 		return err
@@ -142,7 +146,9 @@ func (c *Client) UpdateSubscription(ctx context.Context, updatedSubscription Sub
 
 // GetSubscriptionDetails shows details for a subscription, by ID.
 // Endpoint: GET /v1/billing/subscriptions/
-func (c *Client) GetSubscriptionDetails(ctx context.Context, subscriptionID string) (*SubscriptionDetailResp, error) {
+func (c *Client) GetSubscriptionDetails(ctx context.Context, subscriptionID string, opts ...CallOption) (*SubscriptionDetailResp, error) {
+	ctx = withCallOptions(ctx, opts)
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/v1/billing/subscriptions/%s", c.APIBase, subscriptionID), nil)
 	response := &SubscriptionDetailResp{}
 	if err != nil {
@@ -155,7 +161,9 @@ func (c *Client) GetSubscriptionDetails(ctx context.Context, subscriptionID stri
 // Activates the subscription.
 // Doc: https://developer.paypal.com/docs/api/subscriptions/v1/#subscriptions_activate
 // Endpoint: POST /v1/billing/subscriptions/{id}/activate
-func (c *Client) ActivateSubscription(ctx context.Context, subscriptionId, activateReason string) error {
+func (c *Client) ActivateSubscription(ctx context.Context, subscriptionId, activateReason string, opts ...CallOption) error {
+	ctx = withCallOptions(ctx, opts)
+
 	req, err := c.NewRequest(ctx, http.MethodPost, fmt.Sprintf("%s/v1/billing/subscriptions/%s/activate", c.APIBase, subscriptionId), map[string]string{"reason": activateReason})
 	if err != nil {
 		return err
@@ -167,7 +175,9 @@ func (c *Client) ActivateSubscription(ctx context.Context, subscriptionId, activ
 // Cancels the subscription.
 // Doc: https://developer.paypal.com/docs/api/subscriptions/v1/#subscriptions_cancel
 // Endpoint: POST /v1/billing/subscriptions/{id}/cancel
-func (c *Client) CancelSubscription(ctx context.Context, subscriptionId, cancelReason string) error {
+func (c *Client) CancelSubscription(ctx context.Context, subscriptionId, cancelReason string, opts ...CallOption) error {
+	ctx = withCallOptions(ctx, opts)
+
 	req, err := c.NewRequest(ctx, http.MethodPost, fmt.Sprintf("%s/v1/billing/subscriptions/%s/cancel", c.APIBase, subscriptionId), map[string]string{"reason": cancelReason})
 	if err != nil {
 		return err
@@ -179,7 +189,9 @@ func (c *Client) CancelSubscription(ctx context.Context, subscriptionId, cancelR
 // Captures an authorized payment from the subscriber on the subscription.
 // Doc: https://developer.paypal.com/docs/api/subscriptions/v1/#subscriptions_capture
 // Endpoint: POST /v1/billing/subscriptions/{id}/capture
-func (c *Client) CaptureSubscription(ctx context.Context, subscriptionId string, request CaptureRequest) (*SubscriptionCaptureResponse, error) {
+func (c *Client) CaptureSubscription(ctx context.Context, subscriptionId string, request CaptureRequest, opts ...CallOption) (*SubscriptionCaptureResponse, error) {
+	ctx = withCallOptions(ctx, opts)
+
 	req, err := c.NewRequest(ctx, http.MethodPost, fmt.Sprintf("%s/v1/billing/subscriptions/%s/capture", c.APIBase, subscriptionId), request)
 	response := &SubscriptionCaptureResponse{}
 	if err != nil {
@@ -192,7 +204,9 @@ func (c *Client) CaptureSubscription(ctx context.Context, subscriptionId string,
 // Suspends the subscription.
 // Doc: https://developer.paypal.com/docs/api/subscriptions/v1/#subscriptions_suspend
 // Endpoint: POST /v1/billing/subscriptions/{id}/suspend
-func (c *Client) SuspendSubscription(ctx context.Context, subscriptionId, reason string) error {
+func (c *Client) SuspendSubscription(ctx context.Context, subscriptionId, reason string, opts ...CallOption) error {
+	ctx = withCallOptions(ctx, opts)
+
 	req, err := c.NewRequest(ctx, http.MethodPost, fmt.Sprintf("%s/v1/billing/subscriptions/%s/suspend", c.APIBase, subscriptionId), map[string]string{"reason": reason})
 	if err != nil {
 		return err
@@ -204,7 +218,9 @@ func (c *Client) SuspendSubscription(ctx context.Context, subscriptionId, reason
 // Lists transactions for a subscription.
 // Doc: https://developer.paypal.com/docs/api/subscriptions/v1/#subscriptions_transactions
 // Endpoint: GET /v1/billing/subscriptions/{id}/transactions
-func (c *Client) GetSubscriptionTransactions(ctx context.Context, requestParams SubscriptionTransactionsParams) (*SubscriptionTransactionsResponse, error) {
+func (c *Client) GetSubscriptionTransactions(ctx context.Context, requestParams SubscriptionTransactionsParams, opts ...CallOption) (*SubscriptionTransactionsResponse, error) {
+	ctx = withCallOptions(ctx, opts)
+
 	startTime := requestParams.StartTime.Format("2006-01-02T15:04:05Z")
 	endTime := requestParams.EndTime.Format("2006-01-02T15:04:05Z")
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/v1/billing/subscriptions/%s/transactions?start_time=%s&end_time=%s", c.APIBase, requestParams.SubscriptionId, startTime, endTime), nil)
@@ -220,7 +236,9 @@ func (c *Client) GetSubscriptionTransactions(ctx context.Context, requestParams
 // Revise plan or quantity of subscription
 // Doc: https://developer.paypal.com/docs/api/subscriptions/v1/#subscriptions_revise
 // Endpoint: POST /v1/billing/subscriptions/{id}/revise
-func (c *Client) ReviseSubscription(ctx context.Context, subscriptionId string, reviseSubscription SubscriptionBase) (*SubscriptionDetailResp, error) {
+func (c *Client) ReviseSubscription(ctx context.Context, subscriptionId string, reviseSubscription SubscriptionBase, opts ...CallOption) (*SubscriptionDetailResp, error) {
+	ctx = withCallOptions(ctx, opts)
+
 	req, err := c.NewRequest(ctx, http.MethodPost, fmt.Sprintf("%s/v1/billing/subscriptions/%s/revise", c.APIBase, subscriptionId), reviseSubscription)
 	response := &SubscriptionDetailResp{}
 	if err != nil {
@@ -132,7 +132,9 @@ func (p *SubscriptionPlan) GetUpdatePatch() []Patch {
 // CreateSubscriptionPlan creates a subscriptionPlan
 // Doc: https://developer.paypal.com/docs/api/subscriptions/v1/#plans_create
 // Endpoint: POST /v1/billing/plans
-func (c *Client) CreateSubscriptionPlan(ctx context.Context, newPlan SubscriptionPlan) (*CreateSubscriptionPlanResponse, error) {
+func (c *Client) CreateSubscriptionPlan(ctx context.Context, newPlan SubscriptionPlan, opts ...CallOption) (*CreateSubscriptionPlanResponse, error) {
+	ctx = withCallOptions(ctx, opts)
+
 	req, err := c.NewRequest(ctx, http.MethodPost, fmt.Sprintf("%s%s", c.APIBase, "/v1/billing/plans"), newPlan)
 	response := &CreateSubscriptionPlanResponse{}
 	if err != nil {
@@ -145,7 +147,9 @@ func (c *Client) CreateSubscriptionPlan(ctx context.Context, newPlan Subscriptio
 // UpdateSubscriptionPlan. updates a plan
 // Doc: https://developer.paypal.com/docs/api/subscriptions/v1/#plans_patch
 // Endpoint: PATCH /v1/billing/plans/:plan_id
-func (c *Client) UpdateSubscriptionPlan(ctx context.Context, updatedPlan SubscriptionPlan) error {
+func (c *Client) UpdateSubscriptionPlan(ctx context.Context, updatedPlan SubscriptionPlan, opts ...CallOption) error {
+	ctx = withCallOptions(ctx, opts)
+
 	req, err := c.NewRequest(ctx, http.MethodPatch, fmt.Sprintf("%s%s%s", c.APIBase, "/v1/billing/plans/", updatedPlan.ID), updatedPlan.GetUpdatePatch())
 	if err != nil {
 		return err
@@ -157,7 +161,9 @@ func (c *Client) UpdateSubscriptionPlan(ctx context.Context, updatedPlan Subscri
 // UpdateSubscriptionPlan. updates a plan
 // Doc: https://developer.paypal.com/docs/api/subscriptions/v1/#plans_get
 // Endpoint: GET /v1/billing/plans/:plan_id
-func (c *Client) GetSubscriptionPlan(ctx context.Context, planId string) (*SubscriptionPlan, error) {
+func (c *Client) GetSubscriptionPlan(ctx context.Context, planId string, opts ...CallOption) (*SubscriptionPlan, error) {
+	ctx = withCallOptions(ctx, opts)
+
 	req, err := c.NewRequest(ctx, http.MethodGet, fmt.Sprintf("%s%s%s", c.APIBase, "/v1/billing/plans/", planId), nil)
 	response := &SubscriptionPlan{}
 	if err != nil {
@@ -170,7 +176,9 @@ func (c *Client) GetSubscriptionPlan(ctx context.Context, planId string) (*Subsc
 // List all plans
 // Doc: https://developer.paypal.com/docs/api/subscriptions/v1/#plans_list
 // Endpoint: GET /v1/billing/plans
-func (c *Client) ListSubscriptionPlans(ctx context.Context, params *SubscriptionPlanListParameters) (*ListSubscriptionPlansResponse, error) {
+func (c *Client) ListSubscriptionPlans(ctx context.Context, params *SubscriptionPlanListParameters, opts ...CallOption) (*ListSubscriptionPlansResponse, error) {
+	ctx = withCallOptions(ctx, opts)
+
 	req, err := c.NewRequest(ctx, http.MethodGet, fmt.Sprintf("%s%s", c.APIBase, "/v1/billing/plans"), nil)
 	response := &ListSubscriptionPlansResponse{}
 	if err != nil {
@@ -194,7 +202,9 @@ func (c *Client) ListSubscriptionPlans(ctx context.Context, params *Subscription
 // Activates a plan
 // Doc: https://developer.paypal.com/docs/api/subscriptions/v1/#plans_activate
 // Endpoint: POST /v1/billing/plans/{id}/activate
-func (c *Client) ActivateSubscriptionPlan(ctx context.Context, planId string) error {
+func (c *Client) ActivateSubscriptionPlan(ctx context.Context, planId string, opts ...CallOption) error {
+	ctx = withCallOptions(ctx, opts)
+
 	req, err := c.NewRequest(ctx, http.MethodPost, fmt.Sprintf("%s/v1/billing/plans/%s/activate", c.APIBase, planId), nil)
 	if err != nil {
 		return err
@@ -207,7 +217,9 @@ func (c *Client) ActivateSubscriptionPlan(ctx context.Context, planId string) er
 // Deactivates a plan
 // Doc: https://developer.paypal.com/docs/api/subscriptions/v1/#plans_deactivate
 // Endpoint: POST /v1/billing/plans/{id}/deactivate
-func (c *Client) DeactivateSubscriptionPlans(ctx context.Context, planId string) error {
+func (c *Client) DeactivateSubscriptionPlans(ctx context.Context, planId string, opts ...CallOption) error {
+	ctx = withCallOptions(ctx, opts)
+
 	req, err := c.NewRequest(ctx, http.MethodPost, fmt.Sprintf("%s/v1/billing/plans/%s/deactivate", c.APIBase, planId), nil)
 	if err != nil {
 		return err
@@ -220,7 +232,9 @@ func (c *Client) DeactivateSubscriptionPlans(ctx context.Context, planId string)
 // Updates pricing for a plan. For example, you can update a regular billing cycle from $5 per month to $7 per month.
 // Doc: https://developer.paypal.com/docs/api/subscriptions/v1/#plans_update-pricing-schemes
 // Endpoint: POST /v1/billing/plans/{id}/update-pricing-schemes
-func (c *Client) UpdateSubscriptionPlanPricing(ctx context.Context, planId string, pricingSchemes []PricingSchemeUpdate) error {
+func (c *Client) UpdateSubscriptionPlanPricing(ctx context.Context, planId string, pricingSchemes []PricingSchemeUpdate, opts ...CallOption) error {
+	ctx = withCallOptions(ctx, opts)
+
 	req, err := c.NewRequest(ctx, http.MethodPost, fmt.Sprintf("%s/v1/billing/plans/%s/update-pricing-schemes", c.APIBase, planId), PricingSchemeUpdateRequest{
 		Schemes: pricingSchemes,
 	})
@@ -0,0 +1,95 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/plutov/paypal/v4"
+)
+
+func TestAddTracker(t *testing.T) {
+	ctx := context.Background()
+	server := createTestServer()
+	defer server.Close()
+
+	client, err := paypal.NewClient(clientId, clientSecret, server.URL)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	client.Token = &paypal.TokenResponse{Token: "dummy"}
+
+	response, err := client.AddTracker(ctx, paypal.Tracker{
+		TransactionID:  "TXN123",
+		TrackingNumber: "443844607820",
+		Status:         paypal.TrackerStatusShipped,
+		Carrier:        paypal.CarrierFedEx,
+	})
+	assertNoError(t, err)
+
+	assertEqual(t, 1, len(response.TrackerIdentifiers))
+	assertEqual(t, "TXN123", response.TrackerIdentifiers[0].TransactionID)
+}
+
+func TestAddTrackersBatch(t *testing.T) {
+	ctx := context.Background()
+	server := createTestServer()
+	defer server.Close()
+
+	client, err := paypal.NewClient(clientId, clientSecret, server.URL)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	client.Token = &paypal.TokenResponse{Token: "dummy"}
+
+	response, err := client.AddTrackersBatch(ctx, []paypal.Tracker{
+		{TransactionID: "TXN123", Status: paypal.TrackerStatusShipped},
+		{TransactionID: "TXN456", Status: paypal.TrackerStatusShipped},
+	})
+	assertNoError(t, err)
+
+	assertEqual(t, 0, len(response.Errors))
+	assertEqual(t, 2, len(response.TrackerIdentifiers))
+	assertEqual(t, "TXN123", response.TrackerIdentifiers[0].TransactionID)
+	assertEqual(t, "TXN456", response.TrackerIdentifiers[1].TransactionID)
+}
+
+func TestGetTracker(t *testing.T) {
+	ctx := context.Background()
+	server := createTestServer()
+	defer server.Close()
+
+	client, err := paypal.NewClient(clientId, clientSecret, server.URL)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	client.Token = &paypal.TokenResponse{Token: "dummy"}
+
+	response, err := client.GetTracker(ctx, paypal.TrackerID("TXN123", "443844607820"))
+	assertNoError(t, err)
+
+	assertEqual(t, "TXN123", response.TransactionID)
+	assertEqual(t, paypal.TrackerStatusShipped, response.Status)
+}
+
+func TestUpdateTracker(t *testing.T) {
+	ctx := context.Background()
+	server := createTestServer()
+	defer server.Close()
+
+	client, err := paypal.NewClient(clientId, clientSecret, server.URL)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	client.Token = &paypal.TokenResponse{Token: "dummy"}
+
+	err = client.UpdateTracker(ctx, paypal.Tracker{
+		TransactionID:  "TXN123",
+		TrackingNumber: "443844607820",
+		Status:         paypal.TrackerStatusCancelled,
+	})
+	assertNoError(t, err)
+}
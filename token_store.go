@@ -0,0 +1,403 @@
+package paypal
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TokenLeaseTTL is how long a process holds the right to refresh a shared access token.
+// Other processes sharing the TokenStore wait for the new token at most that long.
+const TokenLeaseTTL = 30 * time.Second
+
+// tokenLeasePollInterval is how often a process waiting for a lease holder checks the TokenStore
+const tokenLeasePollInterval = 100 * time.Millisecond
+
+type (
+	// StoredToken is an access token kept in a TokenStore
+	StoredToken struct {
+		Token     *TokenResponse `json:"token"`
+		ExpiresAt time.Time      `json:"expires_at"`
+	}
+
+	// TokenStore shares access tokens between clients, possibly running in different processes.
+	// Keys identify the credentials (client ID and API base) a token was issued for.
+	TokenStore interface {
+		// Load returns the token saved for the key, or nil if there is none
+		Load(ctx context.Context, key string) (*StoredToken, error)
+		// Save replaces the token saved for the key
+		Save(ctx context.Context, key string, token *StoredToken) error
+		// Invalidate removes the token saved for the key, but only if it's still the given access token,
+		// so a token already refreshed by another process is kept
+		Invalidate(ctx context.Context, key string, accessToken string) error
+		// AcquireLease tries to become the only refresher of the key token for ttl.
+		// It returns the lease identifying the holder, or "" if another holder has a lease which is not expired yet.
+		AcquireLease(ctx context.Context, key string, ttl time.Duration) (string, error)
+		// ReleaseLease gives up a lease returned by AcquireLease. A lease which expired
+		// and was taken over by another holder is left alone.
+		ReleaseLease(ctx context.Context, key string, lease string) error
+	}
+
+	// MemoryTokenStore is a TokenStore shared by clients of a single process
+	MemoryTokenStore struct {
+		mu     sync.Mutex
+		tokens map[string]StoredToken
+		leases map[string]tokenLease
+	}
+
+	// FileTokenStore is a TokenStore keeping tokens in a directory,
+	// which can be shared by processes running on the same host or mounting the same volume
+	FileTokenStore struct {
+		dir string
+	}
+
+	tokenLease struct {
+		owner     string
+		expiresAt time.Time
+	}
+)
+
+// valid reports whether the token can be used for at least RequestNewTokenBeforeExpiresIn.
+// Tokens without an expiration time never expire.
+func (t *StoredToken) valid() bool {
+	return t != nil && t.Token != nil && t.Token.Token != "" &&
+		(t.ExpiresAt.IsZero() || time.Until(t.ExpiresAt) >= RequestNewTokenBeforeExpiresIn)
+}
+
+// NewMemoryTokenStore returns an empty MemoryTokenStore
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{
+		tokens: make(map[string]StoredToken),
+		leases: make(map[string]tokenLease),
+	}
+}
+
+// Load implements TokenStore
+func (s *MemoryTokenStore) Load(ctx context.Context, key string) (*StoredToken, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	token, ok := s.tokens[key]
+	if !ok {
+		return nil, nil
+	}
+	return &token, nil
+}
+
+// Save implements TokenStore
+func (s *MemoryTokenStore) Save(ctx context.Context, key string, token *StoredToken) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokens[key] = *token
+	return nil
+}
+
+// Invalidate implements TokenStore
+func (s *MemoryTokenStore) Invalidate(ctx context.Context, key string, accessToken string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if token, ok := s.tokens[key]; ok && token.Token != nil && token.Token.Token == accessToken {
+		delete(s.tokens, key)
+	}
+	return nil
+}
+
+// AcquireLease implements TokenStore
+func (s *MemoryTokenStore) AcquireLease(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	owner, err := newRequestID()
+	if err != nil {
+		return "", err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if lease, ok := s.leases[key]; ok && time.Now().Before(lease.expiresAt) {
+		return "", nil
+	}
+	s.leases[key] = tokenLease{owner: owner, expiresAt: time.Now().Add(ttl)}
+	return owner, nil
+}
+
+// ReleaseLease implements TokenStore
+func (s *MemoryTokenStore) ReleaseLease(ctx context.Context, key string, lease string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if current, ok := s.leases[key]; ok && current.owner == lease {
+		delete(s.leases, key)
+	}
+	return nil
+}
+
+// NewFileTokenStore returns a FileTokenStore keeping tokens in dir, the directory is created if needed
+func NewFileTokenStore(dir string) (*FileTokenStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+
+	return &FileTokenStore{dir: dir}, nil
+}
+
+func (s *FileTokenStore) path(key string, ext string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(s.dir, "paypal-token-"+hex.EncodeToString(sum[:16])+ext)
+}
+
+// Load implements TokenStore
+func (s *FileTokenStore) Load(ctx context.Context, key string) (*StoredToken, error) {
+	data, err := os.ReadFile(s.path(key, ".json"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	token := &StoredToken{}
+	if err := json.Unmarshal(data, token); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// Save implements TokenStore. The file is replaced atomically so readers never see a partial token.
+func (s *FileTokenStore) Save(ctx context.Context, key string, token *StoredToken) error {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(s.dir, "paypal-token-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.path(key, ".json"))
+}
+
+// Invalidate implements TokenStore
+func (s *FileTokenStore) Invalidate(ctx context.Context, key string, accessToken string) error {
+	token, err := s.Load(ctx, key)
+	if err != nil || token == nil || token.Token == nil || token.Token.Token != accessToken {
+		return err
+	}
+
+	if err := os.Remove(s.path(key, ".json")); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// AcquireLease implements TokenStore. The lease is a file holding its owner and expiration time, created exclusively.
+// A lease left behind by a crashed process is taken over once it expires.
+func (s *FileTokenStore) AcquireLease(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	owner, err := newRequestID()
+	if err != nil {
+		return "", err
+	}
+	path := s.path(key, ".lease")
+	content := []byte(owner + " " + time.Now().Add(ttl).Format(time.RFC3339Nano))
+
+	for range 2 {
+		created, err := s.createLease(path, content)
+		if err != nil {
+			return "", err
+		}
+		if created {
+			return owner, nil
+		}
+
+		data, err := os.ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		if lease, ok := parseTokenLease(data); ok && time.Now().Before(lease.expiresAt) {
+			return "", nil
+		}
+
+		// Only the process which moves the expired lease away takes it over
+		if removed, err := s.removeLease(path, owner, func(current []byte) bool {
+			return bytes.Equal(current, data)
+		}); !removed || err != nil {
+			return "", err
+		}
+	}
+
+	return "", nil
+}
+
+// ReleaseLease implements TokenStore
+func (s *FileTokenStore) ReleaseLease(ctx context.Context, key string, lease string) error {
+	_, err := s.removeLease(s.path(key, ".lease"), lease, func(current []byte) bool {
+		held, ok := parseTokenLease(current)
+		return ok && held.owner == lease
+	})
+	return err
+}
+
+// createLease creates the lease file with its content in one step, it returns false if the file exists
+func (s *FileTokenStore) createLease(path string, content []byte) (bool, error) {
+	tmp, err := os.CreateTemp(s.dir, "paypal-lease-*.tmp")
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+
+	if _, err := tmp.Write(content); err != nil {
+		_ = tmp.Close()
+		return false, err
+	}
+	if err := tmp.Close(); err != nil {
+		return false, err
+	}
+
+	err = os.Link(tmp.Name(), path)
+	if errors.Is(err, fs.ErrExist) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// removeLease atomically moves the lease file away and deletes it if match accepts its content,
+// otherwise it's put back unless a new lease was created in the meantime
+func (s *FileTokenStore) removeLease(path string, owner string, match func(content []byte) bool) (bool, error) {
+	moved := path + "." + owner
+	if err := os.Rename(path, moved); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	defer func() {
+		_ = os.Remove(moved)
+	}()
+
+	content, err := os.ReadFile(moved)
+	if err != nil {
+		return false, err
+	}
+	if match(content) {
+		return true, nil
+	}
+
+	if err := os.Link(moved, path); err != nil && !errors.Is(err, fs.ErrExist) {
+		return false, err
+	}
+	return false, nil
+}
+
+// parseTokenLease decodes the content of a lease file written by FileTokenStore.AcquireLease
+func parseTokenLease(content []byte) (tokenLease, bool) {
+	owner, expiresAt, ok := strings.Cut(string(content), " ")
+	if !ok {
+		return tokenLease{}, false
+	}
+	t, err := time.Parse(time.RFC3339Nano, expiresAt)
+	if err != nil {
+		return tokenLease{}, false
+	}
+	return tokenLease{owner: owner, expiresAt: t}, true
+}
+
+// SetTokenStore shares access tokens of the client through the store.
+// Clients created with the same ClientID and APIBase reuse one token and refresh it together.
+func (c *Client) SetTokenStore(store TokenStore) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.tokenStore = store
+}
+
+// tokenStoreKey identifies the credentials of the client in a TokenStore
+func (c *Client) tokenStoreKey() string {
+	return c.ClientID + "@" + c.APIBase
+}
+
+// loadStoredToken applies the token from the TokenStore to the client
+// if it is valid and differs from the current access token
+func (c *Client) loadStoredToken(ctx context.Context, store TokenStore, current string) (bool, error) {
+	stored, err := store.Load(ctx, c.tokenStoreKey())
+	if err != nil || !stored.valid() || stored.Token.Token == current {
+		return false, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.Token = stored.Token
+	c.tokenExpiresAt = stored.ExpiresAt
+	return true, nil
+}
+
+// refreshStoredToken replaces the current access token, coordinating with other clients sharing the TokenStore:
+// only the lease holder requests a token, others wait for it to appear in the store.
+func (c *Client) refreshStoredToken(ctx context.Context, store TokenStore, current string) error {
+	key := c.tokenStoreKey()
+
+	if ok, err := c.loadStoredToken(ctx, store, current); ok || err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(TokenLeaseTTL)
+	for {
+		lease, err := store.AcquireLease(ctx, key, TokenLeaseTTL)
+		if err != nil {
+			return err
+		}
+		if lease != "" {
+			defer func() {
+				_ = store.ReleaseLease(context.WithoutCancel(ctx), key, lease)
+			}()
+			break
+		}
+		if time.Now().After(deadline) {
+			// The lease holder did not deliver a token in time, get our own
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(tokenLeasePollInterval):
+		}
+
+		if ok, err := c.loadStoredToken(ctx, store, current); ok || err != nil {
+			return err
+		}
+	}
+	// Another process could have saved a token while we were waiting for the lease
+	if ok, err := c.loadStoredToken(ctx, store, current); ok || err != nil {
+		return err
+	}
+
+	_, err := c.GetAccessToken(ctx)
+	return err
+}
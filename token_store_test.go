@@ -0,0 +1,159 @@
+package paypal
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTokenTestServer(t *testing.T, tokenRequests *atomic.Int32, handler http.HandlerFunc) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/oauth2/token" {
+			n := tokenRequests.Add(1)
+			_, _ = w.Write([]byte(`{"access_token":"token-` + strconv.Itoa(int(n)) + `","token_type":"Bearer","expires_in":3600}`))
+			return
+		}
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestSendWithAuth_SharesTokenThroughStore(t *testing.T) {
+	var tokenRequests atomic.Int32
+	server := newTokenTestServer(t, &tokenRequests, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"O-1"}`))
+	})
+
+	store := NewMemoryTokenStore()
+	for range 3 {
+		c, err := NewClient("clientID", "secret", server.URL)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+		c.SetTokenStore(store)
+
+		if _, err := c.GetOrder(context.Background(), "O-1"); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+
+	if tokenRequests.Load() != 1 {
+		t.Errorf("got %d token requests, wanted 1", tokenRequests.Load())
+	}
+}
+
+func TestSendWithAuth_RetriesOnceWithNewTokenAfter401(t *testing.T) {
+	var tokenRequests, attempts atomic.Int32
+	server := newTokenTestServer(t, &tokenRequests, func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		if r.Header.Get("Authorization") != "Bearer token-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"O-1"}`))
+	})
+
+	c, err := NewClient("clientID", "secret", server.URL)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	store := NewMemoryTokenStore()
+	c.SetTokenStore(store)
+
+	if _, err := c.GetOrder(context.Background(), "O-1"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if tokenRequests.Load() != 2 || attempts.Load() != 2 {
+		t.Errorf("got %d token requests and %d attempts, wanted 2 and 2", tokenRequests.Load(), attempts.Load())
+	}
+
+	stored, _ := store.Load(context.Background(), c.tokenStoreKey())
+	if stored == nil || stored.Token.Token != "token-2" {
+		t.Errorf("expected the new token to be stored, got %+v", stored)
+	}
+}
+
+func TestFileTokenStore_Lease(t *testing.T) {
+	store, err := NewFileTokenStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+
+	testTokenStoreLease(t, store)
+}
+
+func TestMemoryTokenStore_Lease(t *testing.T) {
+	testTokenStoreLease(t, NewMemoryTokenStore())
+}
+
+func testTokenStoreLease(t *testing.T, store TokenStore) {
+	ctx := context.Background()
+
+	lease, err := store.AcquireLease(ctx, "key", time.Minute)
+	if lease == "" || err != nil {
+		t.Fatalf("expected to acquire the lease, got %q %v", lease, err)
+	}
+	if other, _ := store.AcquireLease(ctx, "key", time.Minute); other != "" {
+		t.Fatal("expected the lease to be held")
+	}
+	if err := store.ReleaseLease(ctx, "key", lease); err != nil {
+		t.Fatalf("failed to release the lease: %v", err)
+	}
+
+	expired, _ := store.AcquireLease(ctx, "key", -time.Second)
+	if expired == "" {
+		t.Fatal("expected to acquire the released lease")
+	}
+	current, _ := store.AcquireLease(ctx, "key", time.Minute)
+	if current == "" || current == expired {
+		t.Fatal("expected to take over the expired lease")
+	}
+
+	// The previous holder must not release the lease it lost
+	if err := store.ReleaseLease(ctx, "key", expired); err != nil {
+		t.Fatalf("failed to release the lease: %v", err)
+	}
+	if other, _ := store.AcquireLease(ctx, "key", time.Minute); other != "" {
+		t.Fatal("expected the lease taken over to be kept")
+	}
+	if err := store.ReleaseLease(ctx, "key", current); err != nil {
+		t.Fatalf("failed to release the lease: %v", err)
+	}
+	if again, _ := store.AcquireLease(ctx, "key", time.Minute); again == "" {
+		t.Fatal("expected to acquire the released lease")
+	}
+}
+
+func TestFileTokenStore_SaveLoadInvalidate(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewFileTokenStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+
+	expiresAt := time.Now().Add(time.Hour).Round(time.Second)
+	if err := store.Save(ctx, "key", &StoredToken{Token: &TokenResponse{Token: "abc", ExpiresIn: 3600}, ExpiresAt: expiresAt}); err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+
+	stored, err := store.Load(ctx, "key")
+	if err != nil || stored.Token.Token != "abc" || !stored.ExpiresAt.Equal(expiresAt) {
+		t.Fatalf("unexpected stored token %+v, %v", stored, err)
+	}
+
+	_ = store.Invalidate(ctx, "key", "other")
+	if stored, _ := store.Load(ctx, "key"); stored == nil {
+		t.Fatal("token must survive invalidation of another token")
+	}
+	_ = store.Invalidate(ctx, "key", "abc")
+	if stored, _ := store.Load(ctx, "key"); stored != nil {
+		t.Fatal("expected token to be invalidated")
+	}
+}
@@ -0,0 +1,86 @@
+package paypal
+
+import (
+	"context"
+	"fmt"
+)
+
+// TrackerID returns the ID of the tracker of a transaction used by GetTracker and UpdateTracker
+func TrackerID(transactionID, trackingNumber string) string {
+	return transactionID + "-" + trackingNumber
+}
+
+// AddTracker adds tracking information for a PayPal transaction
+// Endpoint: POST /v1/shipping/trackers
+func (c *Client) AddTracker(ctx context.Context, tracker Tracker, opts ...CallOption) (*AddTrackersResponse, error) {
+	ctx = withCallOptions(ctx, opts)
+
+	resp := &AddTrackersResponse{}
+
+	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s", c.APIBase, "/v1/shipping/trackers"), map[string][]Tracker{
+		"trackers": {tracker},
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	if err = c.SendWithAuth(req, resp); err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+// AddTrackersBatch adds tracking information for up to 20 PayPal transactions,
+// trackers which could not be added are listed in the Errors of the response
+// Endpoint: POST /v1/shipping/trackers-batch
+func (c *Client) AddTrackersBatch(ctx context.Context, trackers []Tracker, opts ...CallOption) (*AddTrackersResponse, error) {
+	ctx = withCallOptions(ctx, opts)
+
+	resp := &AddTrackersResponse{}
+
+	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s", c.APIBase, "/v1/shipping/trackers-batch"), map[string][]Tracker{
+		"trackers": trackers,
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	if err = c.SendWithAuth(req, resp); err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+// GetTracker shows tracking information by tracker ID, see TrackerID
+// Endpoint: GET /v1/shipping/trackers/ID
+func (c *Client) GetTracker(ctx context.Context, trackerID string, opts ...CallOption) (*Tracker, error) {
+	ctx = withCallOptions(ctx, opts)
+
+	tracker := &Tracker{}
+
+	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s%s", c.APIBase, "/v1/shipping/trackers/"+trackerID), nil)
+	if err != nil {
+		return tracker, err
+	}
+
+	if err = c.SendWithAuth(req, tracker); err != nil {
+		return tracker, err
+	}
+
+	return tracker, nil
+}
+
+// UpdateTracker updates or cancels tracking information, set the Status to TrackerStatusCancelled to cancel it
+// Endpoint: PUT /v1/shipping/trackers/ID
+func (c *Client) UpdateTracker(ctx context.Context, tracker Tracker, opts ...CallOption) error {
+	ctx = withCallOptions(ctx, opts)
+
+	req, err := c.NewRequest(ctx, "PUT", fmt.Sprintf("%s%s", c.APIBase, "/v1/shipping/trackers/"+TrackerID(tracker.TransactionID, tracker.TrackingNumber)), tracker)
+	if err != nil {
+		return err
+	}
+
+	return c.SendWithAuth(req, nil)
+}
@@ -36,7 +36,9 @@ type TransactionSearchResponse struct {
 
 // ListTransactions - Use this to search PayPal transactions from the last 31 days.
 // Endpoint: GET /v1/reporting/transactions
-func (c *Client) ListTransactions(ctx context.Context, req *TransactionSearchRequest) (*TransactionSearchResponse, error) {
+func (c *Client) ListTransactions(ctx context.Context, req *TransactionSearchRequest, opts ...CallOption) (*TransactionSearchResponse, error) {
+	ctx = withCallOptions(ctx, opts)
+
 	response := &TransactionSearchResponse{}
 
 	r, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s%s", c.APIBase, "/v1/reporting/transactions"), nil)
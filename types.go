@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"strings"
 	"sync"
@@ -19,6 +20,13 @@ const (
 
 	// RequestNewTokenBeforeExpiresIn is used by SendWithAuth and try to get new Token when it's about to expire
 	RequestNewTokenBeforeExpiresIn = time.Duration(60) * time.Second
+
+	// RefreshTokenInBackgroundBeforeExpiresIn is used by SendWithAuth to get new Token in the background,
+	// the current Token is still used until RequestNewTokenBeforeExpiresIn
+	RefreshTokenInBackgroundBeforeExpiresIn = time.Duration(5) * time.Minute
+
+	// tokenRefreshTimeout limits a token request shared by concurrent callers of SendWithAuth
+	tokenRefreshTimeout = time.Minute
 )
 
 // Possible values for `no_shipping` in InputFields
@@ -146,6 +154,25 @@ const (
 	LinkRelActionURL string = "action_url"
 )
 
+// Possible values of `vetting_status` of the products in a MerchantIntegration
+//
+// https://developer.paypal.com/docs/api/partner-referrals/v1/#merchant-integration_status
+const (
+	VettingStatusSubscribed   string = "SUBSCRIBED"
+	VettingStatusApproved     string = "APPROVED"
+	VettingStatusPending      string = "PENDING"
+	VettingStatusInReview     string = "IN_REVIEW"
+	VettingStatusNeedMoreData string = "NEED_MORE_DATA"
+	VettingStatusDenied       string = "DENIED"
+)
+
+// Possible values of `status` of the capabilities in a MerchantIntegration
+const (
+	CapabilityStatusActive    string = "ACTIVE"
+	CapabilityStatusSuspended string = "SUSPENDED"
+	CapabilityStatusRevoked   string = "REVOKED"
+)
+
 const (
 	AncorTypeApplication string = "APPLICATION"
 	AncorTypeAccount     string = "ACCOUNT"
@@ -563,11 +590,90 @@ type (
 		PaymentSource *PaymentSource `json:"payment_source"`
 	}
 
-	// CaptureOrderMockResponse - https://developer.paypal.com/docs/api-basics/sandbox/request-headers/#test-api-error-handling-routines
-	CaptureOrderMockResponse struct {
+	// Tracker - https://developer.paypal.com/docs/api/tracking/v1/#definition-tracker
+	Tracker struct {
+		TransactionID           string        `json:"transaction_id"`
+		TrackingNumber          string        `json:"tracking_number,omitempty"`
+		TrackingNumberType      string        `json:"tracking_number_type,omitempty"`
+		Status                  TrackerStatus `json:"status"`
+		ShipmentDate            string        `json:"shipment_date,omitempty"`
+		Carrier                 Carrier       `json:"carrier,omitempty"`
+		CarrierNameOther        string        `json:"carrier_name_other,omitempty"`
+		PostagePaymentID        string        `json:"postage_payment_id,omitempty"`
+		NotifyBuyer             bool          `json:"notify_buyer,omitempty"`
+		Quantity                int           `json:"quantity,omitempty"`
+		TrackingNumberValidated bool          `json:"tracking_number_validated,omitempty"`
+		TrackingURL             string        `json:"tracking_url,omitempty"`
+		LastUpdatedTime         *time.Time    `json:"last_updated_time,omitempty"`
+		Links                   []Link        `json:"links,omitempty"`
+	}
+
+	// TrackerIdentifier - https://developer.paypal.com/docs/api/tracking/v1/#definition-tracker_identifier
+	TrackerIdentifier struct {
+		TransactionID  string `json:"transaction_id"`
+		TrackingNumber string `json:"tracking_number,omitempty"`
+		Links          []Link `json:"links,omitempty"`
+	}
+
+	// AddTrackersResponse - https://developer.paypal.com/docs/api/tracking/v1/#trackers-batch_post
+	AddTrackersResponse struct {
+		TrackerIdentifiers []TrackerIdentifier `json:"tracker_identifiers,omitempty"`
+		Errors             []TrackerError      `json:"errors,omitempty"`
+		Links              []Link              `json:"links,omitempty"`
+	}
+
+	// TrackerError is the error of a tracker which could not be added by AddTrackersBatch
+	TrackerError struct {
+		Name    string                `json:"name"`
+		DebugID string                `json:"debug_id"`
+		Message string                `json:"message"`
+		Details []ErrorResponseDetail `json:"details,omitempty"`
+	}
+
+	// MockResponse - https://developer.paypal.com/docs/api-basics/sandbox/request-headers/#test-api-error-handling-routines
+	MockResponse struct {
 		MockApplicationCodes string `json:"mock_application_codes"`
 	}
 
+	// CaptureOrderMockResponse is the MockResponse of CaptureOrderWithPaypalRequestId
+	CaptureOrderMockResponse = MockResponse
+
+	// ConfirmPaymentSourceRequest - https://developer.paypal.com/docs/api/orders/v2/#orders_confirm
+	ConfirmPaymentSourceRequest struct {
+		PaymentSource         *PaymentSource      `json:"payment_source"`
+		ProcessingInstruction string              `json:"processing_instruction,omitempty"`
+		ApplicationContext    *ApplicationContext `json:"application_context,omitempty"`
+	}
+
+	// OrderTrackerRequest - https://developer.paypal.com/docs/api/orders/v2/#orders_track_create
+	OrderTrackerRequest struct {
+		CaptureID        string             `json:"capture_id"`
+		TrackingNumber   string             `json:"tracking_number,omitempty"`
+		Carrier          Carrier            `json:"carrier,omitempty"`
+		CarrierNameOther string             `json:"carrier_name_other,omitempty"`
+		NotifyPayer      bool               `json:"notify_payer,omitempty"`
+		Items            []OrderTrackerItem `json:"items,omitempty"`
+	}
+
+	// OrderTrackerItem - https://developer.paypal.com/docs/api/orders/v2/#definition-tracker_item
+	OrderTrackerItem struct {
+		Name     string `json:"name,omitempty"`
+		Quantity string `json:"quantity,omitempty"`
+		SKU      string `json:"sku,omitempty"`
+		URL      string `json:"url,omitempty"`
+		ImageURL string `json:"image_url,omitempty"`
+	}
+
+	// OrderTracker - https://developer.paypal.com/docs/api/orders/v2/#definition-order_tracker_response
+	OrderTracker struct {
+		ID         string             `json:"id,omitempty"`
+		Status     OrderTrackerStatus `json:"status,omitempty"`
+		Items      []OrderTrackerItem `json:"items,omitempty"`
+		Links      []Link             `json:"links,omitempty"`
+		CreateTime *time.Time         `json:"create_time,omitempty"`
+		UpdateTime *time.Time         `json:"update_time,omitempty"`
+	}
+
 	// RefundOrderRequest - https://developer.paypal.com/docs/api/payments/v2/#captures_refund
 	RefundCaptureRequest struct {
 		Amount      *Money `json:"amount,omitempty"`
@@ -646,6 +752,24 @@ type (
 		Token                *TokenResponse
 		tokenExpiresAt       time.Time
 		returnRepresentation bool
+		autoRequestID        bool
+		scopePreflight       bool
+		webhookID            string
+		rateLimiter          *RateLimiter
+		circuitBreaker       *CircuitBreaker
+		retryPolicy          *RetryPolicy
+		tokenStore           TokenStore
+		jwksSource           JWKSSource
+		tokenRefresh         *tokenRefresh
+		middlewares          []Middleware
+		logger               *slog.Logger
+		logOptions           *LogOptions
+	}
+
+	// tokenRefresh is a token request shared by all callers waiting for a new token
+	tokenRefresh struct {
+		done chan struct{}
+		err  error
 	}
 
 	// CreditCard struct
@@ -908,6 +1032,7 @@ type (
 		Intent        string                 `json:"intent,omitempty"`
 		Payer         *PayerWithNameAndPhone `json:"payer,omitempty"`
 		PurchaseUnits []PurchaseUnit         `json:"purchase_units,omitempty"`
+		PaymentSource *PaymentSource         `json:"payment_source,omitempty"`
 		Links         []Link                 `json:"links,omitempty"`
 		CreateTime    *time.Time             `json:"create_time,omitempty"`
 		UpdateTime    *time.Time             `json:"update_time,omitempty"`
@@ -941,12 +1066,14 @@ type (
 		Amount                    *PurchaseUnitAmount        `json:"amount,omitempty"`
 		SellerProtection          *SellerProtection          `json:"seller_protection,omitempty"`
 		SellerReceivableBreakdown *SellerReceivableBreakdown `json:"seller_receivable_breakdown,omitempty"`
+		Links                     []Link                     `json:"links,omitempty"`
 	}
 
 	// CapturedPayments has the amounts for a captured order
 	CapturedPayments struct {
-		Authorizations []Authorization `json:"authorizations,omitempty"`
-		Captures       []CaptureAmount `json:"captures,omitempty"`
+		Authorizations []Authorization  `json:"authorizations,omitempty"`
+		Captures       []CaptureAmount  `json:"captures,omitempty"`
+		Refunds        []RefundResponse `json:"refunds,omitempty"`
 	}
 
 	// CapturedPurchaseItem are items for a captured order
@@ -1158,10 +1285,25 @@ type (
 
 	// RefundResponse .
 	RefundResponse struct {
-		ID     string              `json:"id,omitempty"`
-		Amount *PurchaseUnitAmount `json:"amount,omitempty"`
-		Status string              `json:"status,omitempty"`
-		Links  []Link              `json:"links,omitempty"`
+		ID                     string                  `json:"id,omitempty"`
+		Amount                 *PurchaseUnitAmount     `json:"amount,omitempty"`
+		Status                 string                  `json:"status,omitempty"`
+		StatusDetails          *CaptureStatusDetails   `json:"status_details,omitempty"`
+		InvoiceID              string                  `json:"invoice_id,omitempty"`
+		NoteToPayer            string                  `json:"note_to_payer,omitempty"`
+		SellerPayableBreakdown *SellerPayableBreakdown `json:"seller_payable_breakdown,omitempty"`
+		Links                  []Link                  `json:"links,omitempty"`
+		CreateTime             *time.Time              `json:"create_time,omitempty"`
+		UpdateTime             *time.Time              `json:"update_time,omitempty"`
+	}
+
+	// SellerPayableBreakdown - https://developer.paypal.com/docs/api/payments/v2/#definition-seller_payable_breakdown
+	SellerPayableBreakdown struct {
+		GrossAmount         *Money        `json:"gross_amount,omitempty"`
+		PaypalFee           *Money        `json:"paypal_fee,omitempty"`
+		NetAmount           *Money        `json:"net_amount,omitempty"`
+		PlatformFees        []PlatformFee `json:"platform_fees,omitempty"`
+		TotalRefundedAmount *Money        `json:"total_refunded_amount,omitempty"`
 	}
 
 	// Related struct
@@ -1240,8 +1382,9 @@ type (
 
 	// ShippingDetail struct
 	ShippingDetail struct {
-		Name    *Name                          `json:"name,omitempty"`
-		Address *ShippingDetailAddressPortable `json:"address,omitempty"`
+		Name     *Name                          `json:"name,omitempty"`
+		Address  *ShippingDetailAddressPortable `json:"address,omitempty"`
+		Trackers []OrderTracker                 `json:"trackers,omitempty"`
 	}
 
 	// Subscriber struct
@@ -1260,6 +1403,12 @@ type (
 		Token        string         `json:"access_token"`
 		Type         string         `json:"token_type"`
 		ExpiresIn    expirationTime `json:"expires_in"`
+		// IDToken is returned for users who authorized the app with the openid scope
+		IDToken string `json:"id_token,omitempty"`
+		// Scope is the space separated list of scopes granted to the app, see Scopes
+		Scope string `json:"scope,omitempty"`
+		AppID string `json:"app_id,omitempty"`
+		Nonce string `json:"nonce,omitempty"`
 	}
 
 	// Transaction struct
@@ -1427,6 +1576,67 @@ type (
 		Links []Link `json:"links,omitempty"`
 	}
 
+	// PartnerReferral is the data of a partner referral returned by GetPartnerReferral
+	PartnerReferral struct {
+		PartnerReferralID string          `json:"partner_referral_id"`
+		SubmitterPayerID  string          `json:"submitter_payer_id,omitempty"`
+		ReferralData      ReferralRequest `json:"referral_data"`
+		Links             []Link          `json:"links,omitempty"`
+	}
+
+	// MerchantIntegration is the onboarding status of a seller returned by GetMerchantIntegration
+	MerchantIntegration struct {
+		MerchantID            string                       `json:"merchant_id"`
+		TrackingID            string                       `json:"tracking_id,omitempty"`
+		LegalName             string                       `json:"legal_name,omitempty"`
+		PrimaryEmail          string                       `json:"primary_email,omitempty"`
+		PaymentsReceivable    bool                         `json:"payments_receivable"`
+		PrimaryEmailConfirmed bool                         `json:"primary_email_confirmed"`
+		Products              []MerchantIntegrationProduct `json:"products,omitempty"`
+		Capabilities          []MerchantCapability         `json:"capabilities,omitempty"`
+		OAuthIntegrations     []MerchantOAuthIntegration   `json:"oauth_integrations,omitempty"`
+		GrantedPermissions    []string                     `json:"granted_permissions,omitempty"`
+		Links                 []Link                       `json:"links,omitempty"`
+	}
+
+	// MerchantIntegrationProduct is a product the seller subscribed to and its vetting status
+	MerchantIntegrationProduct struct {
+		Name          string   `json:"name"`
+		VettingStatus string   `json:"vetting_status,omitempty"`
+		Capabilities  []string `json:"capabilities,omitempty"`
+	}
+
+	// MerchantCapability is a capability of the seller, e.g. CUSTOM_CARD_PROCESSING
+	MerchantCapability struct {
+		Name   string `json:"name"`
+		Status string `json:"status"`
+		Limits []struct {
+			Type string `json:"type"`
+		} `json:"limits,omitempty"`
+	}
+
+	// MerchantOAuthIntegration lists the permissions the seller granted to the partner
+	MerchantOAuthIntegration struct {
+		IntegrationType   string                    `json:"integration_type"`
+		IntegrationMethod string                    `json:"integration_method,omitempty"`
+		Status            string                    `json:"status,omitempty"`
+		OAuthThirdParty   []MerchantOAuthThirdParty `json:"oauth_third_party,omitempty"`
+	}
+
+	// MerchantOAuthThirdParty is the set of scopes granted to a partner client
+	MerchantOAuthThirdParty struct {
+		PartnerClientID  string   `json:"partner_client_id"`
+		MerchantClientID string   `json:"merchant_client_id,omitempty"`
+		Scopes           []string `json:"scopes"`
+	}
+
+	// MerchantIntegrationLookup is the seller found by FindMerchantIntegration
+	MerchantIntegrationLookup struct {
+		MerchantID string `json:"merchant_id"`
+		TrackingID string `json:"tracking_id"`
+		Links      []Link `json:"links,omitempty"`
+	}
+
 	PartnerConfigOverride struct {
 		PartnerLogoURL       string `json:"partner_logo_url,omitempty"`
 		ReturnURL            string `json:"return_url,omitempty"`
@@ -1595,6 +1805,19 @@ func (t JSONTime) MarshalJSON() ([]byte, error) {
 	return []byte(stamp), nil
 }
 
+// MarshalJSON for Patch, remove operations are sent without value
+func (p Patch) MarshalJSON() ([]byte, error) {
+	if p.Operation == "remove" {
+		return json.Marshal(struct {
+			Operation string `json:"op"`
+			Path      string `json:"path"`
+		}{p.Operation, p.Path})
+	}
+
+	type patch Patch
+	return json.Marshal(patch(p))
+}
+
 // UnmarshalJSON for JSONTime, timezone offset is missing a colon ':"
 func (t *JSONTime) UnmarshalJSON(b []byte) error {
 	s := strings.Trim(string(b), `"`)
@@ -7,7 +7,9 @@ import (
 
 // StoreCreditCard func
 // Endpoint: POST /v1/vault/credit-cards
-func (c *Client) StoreCreditCard(ctx context.Context, cc CreditCard) (*CreditCard, error) {
+func (c *Client) StoreCreditCard(ctx context.Context, cc CreditCard, opts ...CallOption) (*CreditCard, error) {
+	ctx = withCallOptions(ctx, opts)
+
 	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s", c.APIBase, "/v1/vault/credit-cards"), cc)
 	if err != nil {
 		return nil, err
@@ -24,7 +26,9 @@ func (c *Client) StoreCreditCard(ctx context.Context, cc CreditCard) (*CreditCar
 
 // DeleteCreditCard func
 // Endpoint: DELETE /v1/vault/credit-cards/credit_card_id
-func (c *Client) DeleteCreditCard(ctx context.Context, id string) error {
+func (c *Client) DeleteCreditCard(ctx context.Context, id string, opts ...CallOption) error {
+	ctx = withCallOptions(ctx, opts)
+
 	req, err := c.NewRequest(ctx, "DELETE", fmt.Sprintf("%s/v1/vault/credit-cards/%s", c.APIBase, id), nil)
 	if err != nil {
 		return err
@@ -39,7 +43,9 @@ func (c *Client) DeleteCreditCard(ctx context.Context, id string) error {
 
 // GetCreditCard func
 // Endpoint: GET /v1/vault/credit-cards/credit_card_id
-func (c *Client) GetCreditCard(ctx context.Context, id string) (*CreditCard, error) {
+func (c *Client) GetCreditCard(ctx context.Context, id string, opts ...CallOption) (*CreditCard, error) {
+	ctx = withCallOptions(ctx, opts)
+
 	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s/v1/vault/credit-cards/%s", c.APIBase, id), nil)
 	if err != nil {
 		return nil, err
@@ -56,7 +62,9 @@ func (c *Client) GetCreditCard(ctx context.Context, id string) (*CreditCard, err
 
 // GetCreditCards func
 // Endpoint: GET /v1/vault/credit-cards
-func (c *Client) GetCreditCards(ctx context.Context, ccf *CreditCardsFilter) (*CreditCards, error) {
+func (c *Client) GetCreditCards(ctx context.Context, ccf *CreditCardsFilter, opts ...CallOption) (*CreditCards, error) {
+	ctx = withCallOptions(ctx, opts)
+
 	page := 1
 	if ccf != nil && ccf.Page > 0 {
 		page = ccf.Page
@@ -82,7 +90,9 @@ func (c *Client) GetCreditCards(ctx context.Context, ccf *CreditCardsFilter) (*C
 
 // PatchCreditCard func
 // Endpoint: PATCH /v1/vault/credit-cards/credit_card_id
-func (c *Client) PatchCreditCard(ctx context.Context, id string, ccf []CreditCardField) (*CreditCard, error) {
+func (c *Client) PatchCreditCard(ctx context.Context, id string, ccf []CreditCardField, opts ...CallOption) (*CreditCard, error) {
+	ctx = withCallOptions(ctx, opts)
+
 	req, err := c.NewRequest(ctx, "PATCH", fmt.Sprintf("%s/v1/vault/credit-cards/%s", c.APIBase, id), ccf)
 	if err != nil {
 		return nil, err
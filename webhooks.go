@@ -12,7 +12,9 @@ import (
 
 // CreateWebhook - Subscribes your webhook listener to events.
 // Endpoint: POST /v1/notifications/webhooks
-func (c *Client) CreateWebhook(ctx context.Context, createWebhookRequest *CreateWebhookRequest) (*Webhook, error) {
+func (c *Client) CreateWebhook(ctx context.Context, createWebhookRequest *CreateWebhookRequest, opts ...CallOption) (*Webhook, error) {
+	ctx = withCallOptions(ctx, opts)
+
 	req, err := c.NewRequest(ctx, http.MethodPost, fmt.Sprintf("%s%s", c.APIBase, "/v1/notifications/webhooks"), createWebhookRequest)
 	webhook := &Webhook{}
 	if err != nil {
@@ -25,7 +27,9 @@ func (c *Client) CreateWebhook(ctx context.Context, createWebhookRequest *Create
 
 // GetWebhook - Shows details for a webhook, by ID.
 // Endpoint: GET /v1/notifications/webhooks/ID
-func (c *Client) GetWebhook(ctx context.Context, webhookID string) (*Webhook, error) {
+func (c *Client) GetWebhook(ctx context.Context, webhookID string, opts ...CallOption) (*Webhook, error) {
+	ctx = withCallOptions(ctx, opts)
+
 	req, err := c.NewRequest(ctx, http.MethodGet, fmt.Sprintf("%s%s%s", c.APIBase, "/v1/notifications/webhooks/", webhookID), nil)
 	webhook := &Webhook{}
 	if err != nil {
@@ -38,7 +42,9 @@ func (c *Client) GetWebhook(ctx context.Context, webhookID string) (*Webhook, er
 
 // UpdateWebhook - Updates a webhook to replace webhook fields with new values.
 // Endpoint: PATCH /v1/notifications/webhooks/ID
-func (c *Client) UpdateWebhook(ctx context.Context, webhookID string, fields []WebhookField) (*Webhook, error) {
+func (c *Client) UpdateWebhook(ctx context.Context, webhookID string, fields []WebhookField, opts ...CallOption) (*Webhook, error) {
+	ctx = withCallOptions(ctx, opts)
+
 	req, err := c.NewRequest(ctx, http.MethodPatch, fmt.Sprintf("%s/v1/notifications/webhooks/%s", c.APIBase, webhookID), fields)
 	webhook := &Webhook{}
 	if err != nil {
@@ -51,7 +57,9 @@ func (c *Client) UpdateWebhook(ctx context.Context, webhookID string, fields []W
 
 // ListWebhooks - Lists webhooks for an app.
 // Endpoint: GET /v1/notifications/webhooks
-func (c *Client) ListWebhooks(ctx context.Context, anchorType string) (*ListWebhookResponse, error) {
+func (c *Client) ListWebhooks(ctx context.Context, anchorType string, opts ...CallOption) (*ListWebhookResponse, error) {
+	ctx = withCallOptions(ctx, opts)
+
 	if len(anchorType) == 0 {
 		anchorType = AncorTypeApplication
 	}
@@ -70,7 +78,9 @@ func (c *Client) ListWebhooks(ctx context.Context, anchorType string) (*ListWebh
 
 // DeleteWebhook - Deletes a webhook, by ID.
 // Endpoint: DELETE /v1/notifications/webhooks/ID
-func (c *Client) DeleteWebhook(ctx context.Context, webhookID string) error {
+func (c *Client) DeleteWebhook(ctx context.Context, webhookID string, opts ...CallOption) error {
+	ctx = withCallOptions(ctx, opts)
+
 	req, err := c.NewRequest(ctx, http.MethodDelete, fmt.Sprintf("%s/v1/notifications/webhooks/%s", c.APIBase, webhookID), nil)
 	if err != nil {
 		return err
@@ -81,8 +91,11 @@ func (c *Client) DeleteWebhook(ctx context.Context, webhookID string) error {
 }
 
 // VerifyWebhookSignature - Use this to verify the signature of a webhook recieved from paypal.
+// The webhook ID set with SetWebhookID is used if webhookID is empty.
 // Endpoint: POST /v1/notifications/verify-webhook-signature
-func (c *Client) VerifyWebhookSignature(ctx context.Context, httpReq *http.Request, webhookID string) (*VerifyWebhookResponse, error) {
+func (c *Client) VerifyWebhookSignature(ctx context.Context, httpReq *http.Request, webhookID string, opts ...CallOption) (*VerifyWebhookResponse, error) {
+	ctx = withCallOptions(ctx, opts)
+
 	type verifyWebhookSignatureRequest struct {
 		AuthAlgo         string          `json:"auth_algo,omitempty"`
 		CertURL          string          `json:"cert_url,omitempty"`
@@ -93,6 +106,10 @@ func (c *Client) VerifyWebhookSignature(ctx context.Context, httpReq *http.Reque
 		Event            json.RawMessage `json:"webhook_event,omitempty"`
 	}
 
+	if webhookID == "" {
+		webhookID = c.webhookID
+	}
+
 	// Read the content
 	var bodyBytes []byte
 	if httpReq.Body != nil {
@@ -129,7 +146,9 @@ func (c *Client) VerifyWebhookSignature(ctx context.Context, httpReq *http.Reque
 
 // GetWebhookEventTypes - Lists all webhook event types.
 // Endpoint: GET /v1/notifications/webhooks-event-types
-func (c *Client) GetWebhookEventTypes(ctx context.Context) (*WebhookEventTypesResponse, error) {
+func (c *Client) GetWebhookEventTypes(ctx context.Context, opts ...CallOption) (*WebhookEventTypesResponse, error) {
+	ctx = withCallOptions(ctx, opts)
+
 	req, err := c.NewRequest(ctx, http.MethodGet, fmt.Sprintf("%s%s", c.APIBase, "/v1/notifications/webhooks-event-types"), nil)
 	q := req.URL.Query()
 
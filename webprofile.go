@@ -8,10 +8,12 @@ import (
 
 // CreateWebProfile creates a new web experience profile in Paypal
 //
-// Allows for the customisation of the payment experience
+// Allows for the customisation of the payment experience.
 //
 // Endpoint: POST /v1/payment-experience/web-profiles
-func (c *Client) CreateWebProfile(ctx context.Context, wp WebProfile) (*WebProfile, error) {
+func (c *Client) CreateWebProfile(ctx context.Context, wp WebProfile, opts ...CallOption) (*WebProfile, error) {
+	ctx = withCallOptions(ctx, opts)
+
 	url := fmt.Sprintf("%s%s", c.APIBase, "/v1/payment-experience/web-profiles")
 	req, err := c.NewRequest(ctx, "POST", url, wp)
 	response := &WebProfile{}
@@ -30,7 +32,9 @@ func (c *Client) CreateWebProfile(ctx context.Context, wp WebProfile) (*WebProfi
 // GetWebProfile gets an exists payment experience from Paypal
 //
 // Endpoint: GET /v1/payment-experience/web-profiles/<profile-id>
-func (c *Client) GetWebProfile(ctx context.Context, profileID string) (*WebProfile, error) {
+func (c *Client) GetWebProfile(ctx context.Context, profileID string, opts ...CallOption) (*WebProfile, error) {
+	ctx = withCallOptions(ctx, opts)
+
 	var wp WebProfile
 
 	url := fmt.Sprintf("%s%s%s", c.APIBase, "/v1/payment-experience/web-profiles/", profileID)
@@ -54,7 +58,9 @@ func (c *Client) GetWebProfile(ctx context.Context, profileID string) (*WebProfi
 // GetWebProfiles retrieves web experience profiles from Paypal
 //
 // Endpoint: GET /v1/payment-experience/web-profiles
-func (c *Client) GetWebProfiles(ctx context.Context) ([]WebProfile, error) {
+func (c *Client) GetWebProfiles(ctx context.Context, opts ...CallOption) ([]WebProfile, error) {
+	ctx = withCallOptions(ctx, opts)
+
 	var wps []WebProfile
 
 	url := fmt.Sprintf("%s%s", c.APIBase, "/v1/payment-experience/web-profiles")
@@ -74,7 +80,8 @@ func (c *Client) GetWebProfiles(ctx context.Context) ([]WebProfile, error) {
 // SetWebProfile sets a web experience profile in Paypal with given id
 //
 // Endpoint: PUT /v1/payment-experience/web-profiles
-func (c *Client) SetWebProfile(ctx context.Context, wp WebProfile) error {
+func (c *Client) SetWebProfile(ctx context.Context, wp WebProfile, opts ...CallOption) error {
+	ctx = withCallOptions(ctx, opts)
 
 	if wp.ID == "" {
 		return fmt.Errorf("paypal: no ID specified for WebProfile")
@@ -98,7 +105,8 @@ func (c *Client) SetWebProfile(ctx context.Context, wp WebProfile) error {
 // DeleteWebProfile deletes a web experience profile from Paypal with given id
 //
 // Endpoint: DELETE /v1/payment-experience/web-profiles
-func (c *Client) DeleteWebProfile(ctx context.Context, profileID string) error {
+func (c *Client) DeleteWebProfile(ctx context.Context, profileID string, opts ...CallOption) error {
+	ctx = withCallOptions(ctx, opts)
 
 	url := fmt.Sprintf("%s%s%s", c.APIBase, "/v1/payment-experience/web-profiles/", profileID)
 